@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/report"
+)
+
+const cannibalizationDescription = "Report keywords that are top-ranked on multiple pages of a site (keyword cannibalization)."
+
+// runCannibalization は複数ページで上位になっているキーワードと競合するページを出力します
+func runCannibalization(args []string) {
+	fs := flag.NewFlagSet("cannibalization", flag.ExitOnError)
+	fs.Usage = customUsage(fs, "cannibalization", cannibalizationDescription)
+	optionUrls := defineFlagSliceValue(fs, "u", "url", "URL to analyze (repeatable)")
+	optionUrlsFile := defineFlagValue("i", "urls-file" /* */, "File with one URL or HTML file path per line", "", fs.String, fs.StringVar)
+	optionDir := defineFlagValue("D", "dir" /*       */, "Directory of saved HTML files (.html, .htm)", "", fs.String, fs.StringVar)
+	optionTop := defineFlagValue("t", "top" /*       */, "Keywords ranked within the top N of a page are compared", report.DefaultCannibalizationTopN, fs.Int, fs.IntVar)
+	optionMinPages := defineFlagValue("m", "min-pages" /* */, "Minimum number of competing pages", 2, fs.Int, fs.IntVar)
+	optionAlgorithm := defineFlagValue("a", "algorithm" /* */, "Keyword scoring algorithm (frequency, textrank, yake)", "frequency", fs.String, fs.StringVar)
+	optionPretty := defineFlagValue("p", "pretty" /*    */, "Format JSON output with indentation", false, fs.Bool, fs.BoolVar)
+	fs.Parse(args)
+
+	sources := append([]string{}, *optionUrls...)
+	if *optionUrlsFile != "" {
+		lines, err := readURLsFile(*optionUrlsFile)
+		if err != nil {
+			handleError(err, "Options")
+			os.Exit(1)
+		}
+		sources = append(sources, lines...)
+	}
+	if *optionDir != "" {
+		files, err := report.HTMLFiles(*optionDir)
+		if err != nil {
+			handleError(err, "Options")
+			os.Exit(1)
+		}
+		sources = append(sources, files...)
+	}
+	if len(sources) == 0 {
+		fs.Usage()
+		os.Exit(0)
+	}
+
+	cfg := analysisConfig(*optionAlgorithm)
+
+	// Ctrl+C (SIGINT) で取得・解析中の処理をキャンセル
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	pages, pageErrors, err := report.AnalyzePagesContext(ctx, sources, *optionTop, analyzer.WithConfig(cfg))
+	if err != nil {
+		handleError(err, "AnalyzePages")
+		exit(err)
+	}
+	result := report.Cannibalization(pages, report.CannibalizationOptions{TopN: *optionTop, MinPages: *optionMinPages, Config: &cfg})
+	result.Errors = pageErrors
+	printJSON(result, *optionPretty)
+}
+
+// readURLsFile は1行に1件の URL（またはファイルのパス）を読み込みます（空行と # で始まる行は無視）
+func readURLsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open URL list '%s': %w", path, err)
+	}
+	defer f.Close()
+	var urls []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read URL list '%s': %w", path, err)
+	}
+	return urls, nil
+}
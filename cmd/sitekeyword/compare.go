@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/report"
+)
+
+const compareDescription = "Compare our page with competitor pages and report keyword gaps (the first --url is ours)."
+
+// runCompare は自サイトと競合サイトのキーワードを比較します
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	fs.Usage = customUsage(fs, "compare", compareDescription)
+	optionUrls := defineFlagSliceValue(fs, "u", "url", Req+"URL to analyze (repeatable; the first one is ours)")
+	optionFormat := defineFlagValue("f", "format" /*    */, "Output format (json, table)", "json", fs.String, fs.StringVar)
+	optionTop := defineFlagValue("t", "top" /*       */, "Number of keywords compared per page", 20, fs.Int, fs.IntVar)
+	optionMinScore := defineFlagValue("s", "min-score" /* */, "Normalized score for a competitor keyword to count as strong", report.DefaultMinCompetitorScore, fs.Float64, fs.Float64Var)
+	optionGap := defineFlagValue("g", "gap" /*       */, "Normalized score difference for our keyword to count as weak", report.DefaultGapThreshold, fs.Float64, fs.Float64Var)
+	optionAlgorithm := defineFlagValue("a", "algorithm" /* */, "Keyword scoring algorithm (frequency, textrank, yake)", "frequency", fs.String, fs.StringVar)
+	optionPretty := defineFlagValue("p", "pretty" /*    */, "Format JSON output with indentation", false, fs.Bool, fs.BoolVar)
+	fs.Parse(args)
+
+	if len(*optionUrls) < 2 {
+		fs.Usage()
+		os.Exit(0)
+	}
+	requireChoice("format", *optionFormat, "json", "table")
+	if err := report.CheckDuplicateURLs(*optionUrls); err != nil {
+		handleError(err, "Compare")
+		os.Exit(1)
+	}
+	cfg := analysisConfig(*optionAlgorithm)
+	// サイト間でスコアをそろえるため、最大値で正規化したスコアで比較する
+	cfg.Normalization = "max"
+
+	// Ctrl+C (SIGINT) で取得・解析中の処理をキャンセル
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	pages, pageErrors, err := report.AnalyzePagesContext(ctx, *optionUrls, *optionTop, analyzer.WithConfig(cfg))
+	if err != nil {
+		handleError(err, "AnalyzePages")
+		exit(err)
+	}
+	if len(pages) == 0 || pages[0].URL != (*optionUrls)[0] {
+		err := fmt.Errorf("Failed to analyze our page '%s'", (*optionUrls)[0])
+		for _, e := range pageErrors {
+			if e.URL == (*optionUrls)[0] {
+				err = fmt.Errorf("Failed to analyze our page '%s': %s", e.URL, e.Error)
+			}
+		}
+		handleError(err, "Compare")
+		os.Exit(1)
+	}
+	result, err := report.Compare(pages[0], pages[1:], report.CompareOptions{MinCompetitorScore: *optionMinScore, GapThreshold: *optionGap, Config: &cfg})
+	if err != nil {
+		handleError(err, "Compare")
+		os.Exit(1)
+	}
+	result.Errors = pageErrors
+
+	if *optionFormat == "table" {
+		if err := result.WriteTable(os.Stdout); err != nil {
+			handleError(err, "WriteTable")
+			os.Exit(1)
+		}
+		return
+	}
+	printJSON(result, *optionPretty)
+}
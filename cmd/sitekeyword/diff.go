@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/report"
+)
+
+const diffDescription = "Diff two analysis results (JSON output of sitekeyword --detail, HTML files or URLs): sitekeyword diff [OPTIONS] OLD NEW"
+
+// runDiff は2つの解析結果のキーワード・タイトル・メタタグの差分を出力します
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = customUsage(fs, "diff", diffDescription)
+	optionFormat := defineFlagValue("f", "format" /*    */, "Output format (text, json)", "text", fs.String, fs.StringVar)
+	optionColor := defineFlagValue("c", "color" /*     */, "Color text output (auto, always, never)", "auto", fs.String, fs.StringVar)
+	optionExitCode := defineFlagValue("e", "exit-code" /* */, "Exit with status 1 if there are differences", false, fs.Bool, fs.BoolVar)
+	optionMinDelta := defineFlagValue("m", "min-delta" /* */, "Ignore score changes smaller than this when the rank is unchanged", 0.0, fs.Float64, fs.Float64Var)
+	optionTop := defineFlagValue("t", "top" /*       */, "Number of keywords to analyze for URLs and HTML files", 20, fs.Int, fs.IntVar)
+	optionAlgorithm := defineFlagValue("a", "algorithm" /* */, "Keyword scoring algorithm for URLs and HTML files (frequency, textrank, yake)", "frequency", fs.String, fs.StringVar)
+	optionPretty := defineFlagValue("p", "pretty" /*    */, "Format JSON output with indentation", false, fs.Bool, fs.BoolVar)
+	sources := parseArgs(fs, args)
+
+	if len(sources) != 2 {
+		fs.Usage()
+		os.Exit(0)
+	}
+	requireChoice("format", *optionFormat, "text", "json")
+	requireChoice("color mode", *optionColor, "auto", "always", "never")
+	color := *optionColor == "always" || (*optionColor == "auto" && isTerminal(os.Stdout))
+	cfg := analysisConfig(*optionAlgorithm)
+
+	// Ctrl+C (SIGINT) で取得・解析中の処理をキャンセル
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	oldSource, newSource := sources[0], sources[1]
+	oldResult, err := report.LoadResultContext(ctx, oldSource, *optionTop, analyzer.WithConfig(cfg))
+	if err != nil {
+		handleError(err, "LoadResult")
+		exit(err)
+	}
+	newResult, err := report.LoadResultContext(ctx, newSource, *optionTop, analyzer.WithConfig(cfg))
+	if err != nil {
+		handleError(err, "LoadResult")
+		exit(err)
+	}
+	d := report.Diff(oldResult, newResult, report.DiffOptions{MinScoreDelta: *optionMinDelta, Config: &cfg})
+	d.Old, d.New = oldSource, newSource
+
+	if *optionFormat == "json" {
+		printJSON(d, *optionPretty)
+	} else if err := d.WriteText(os.Stdout, color); err != nil {
+		handleError(err, "WriteText")
+		os.Exit(1)
+	}
+	if *optionExitCode && d.HasChanges() {
+		os.Exit(1)
+	}
+}
+
+// isTerminal は f が端末か判定します（パイプ・リダイレクト時は色を付けない）
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/xshoji/go-site-keyword/pkg/history"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+const historyDescription = "Show how a page's keywords and scores changed over the saved runs (--save)."
+
+// runHistory は履歴DBに保存したスナップショットからキーワードの推移を出力します
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	fs.Usage = customUsage(fs, "history", historyDescription)
+	optionUrl := defineFlagValue("u", "url" /*         */, "URL to show; lists the saved URLs if omitted", "", fs.String, fs.StringVar)
+	optionHistoryDB := defineFlagValue("H", "history-db" /* */, "Path to the history database", history.DefaultPath(), fs.String, fs.StringVar)
+	optionTop := defineFlagValue("t", "top" /*         */, "Number of top keywords per run to show", 10, fs.Int, fs.IntVar)
+	optionLast := defineFlagValue("n", "last" /*        */, "Number of latest runs to show (0 for all)", 0, fs.Int, fs.IntVar)
+	optionFormat := defineFlagValue("f", "format" /*      */, "Output format (json, table)", "table", fs.String, fs.StringVar)
+	optionPretty := defineFlagValue("p", "pretty" /*      */, "Format JSON output with indentation", false, fs.Bool, fs.BoolVar)
+	fs.Parse(args)
+
+	requireChoice("format", *optionFormat, "json", "table")
+	// 表示のみなので読み取り専用で開く（存在しないパスに空のDBを作らない）
+	store, err := history.OpenReadOnly(*optionHistoryDB)
+	if err != nil {
+		handleError(err, "OpenHistory")
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	if *optionUrl == "" {
+		urls, err := store.URLs()
+		if err != nil {
+			handleError(err, "History")
+			os.Exit(1)
+		}
+		printURLs(urls, *optionFormat, *optionPretty)
+		return
+	}
+	snapshots, err := store.Snapshots(*optionUrl)
+	if err != nil {
+		handleError(err, "History")
+		os.Exit(1)
+	}
+	if *optionLast > 0 && len(snapshots) > *optionLast {
+		snapshots = snapshots[len(snapshots)-*optionLast:]
+	}
+	h := history.NewHistory(*optionUrl, snapshots, *optionTop)
+	if *optionFormat == "table" {
+		if err := h.WriteTable(os.Stdout); err != nil {
+			handleError(err, "WriteTable")
+			os.Exit(1)
+		}
+		return
+	}
+	printJSON(h, *optionPretty)
+}
+
+// printURLs は保存されている URL とスナップショット数を出力します
+func printURLs(urls map[string]int, format string, pretty bool) {
+	if format == "json" {
+		printJSON(urls, pretty)
+		return
+	}
+	names := make([]string, 0, len(urls))
+	for url := range urls {
+		names = append(names, url)
+	}
+	sort.Strings(names)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "URL\tRUNS")
+	for _, url := range names {
+		fmt.Fprintf(tw, "%s\t%d\n", url, urls[url])
+	}
+	tw.Flush()
+}
+
+// saveSnapshot は解析結果を現在時刻のスナップショットとして履歴DBに保存します
+func saveSnapshot(path, url string, result *types.AnalysisResult) error {
+	store, err := history.Open(path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return store.Save(history.Snapshot{URL: url, Time: time.Now().UTC(), Result: result})
+}
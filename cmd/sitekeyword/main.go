@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +17,7 @@ import (
 
 	"github.com/xshoji/go-site-keyword/pkg/analyzer"
 	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/history"
 )
 
 const (
@@ -24,40 +29,111 @@ const (
 var (
 	commandDescription = "A tool for extracting and analyzing keywords from web pages. Fetches titles, meta tags, and identifies top keywords with their relevance scores."
 	// Command options ( the -h, --help option is defined by default in the flag package )
-	optionUrl    = defineFlagValue("u", "url" /*    */, Req+"URL" /*   */, "", flag.String, flag.StringVar)
-	optionPretty = defineFlagValue("p", "pretty" /* */, "Format JSON output with indentation", false, flag.Bool, flag.BoolVar)
-	optionDetail = defineFlagValue("d", "detail" /* */, "Output all details including title and meta tags", false, flag.Bool, flag.BoolVar)
+	optionUrl           = defineFlagValue("u", "url" /*    */, Req+"URL" /*   */, "", flag.String, flag.StringVar)
+	optionPretty        = defineFlagValue("p", "pretty" /* */, "Format JSON output with indentation", false, flag.Bool, flag.BoolVar)
+	optionDetail        = defineFlagValue("d", "detail" /* */, "Output all details including title, meta tags and SEO audit findings", false, flag.Bool, flag.BoolVar)
+	optionAlgorithm     = defineFlagValue("a", "algorithm" /* */, "Keyword scoring algorithm (frequency, textrank, yake)", "frequency", flag.String, flag.StringVar)
+	optionNormalization = defineFlagValue("n", "normalization" /* */, "Normalized score strategy (max, sum, softmax)", "max", flag.String, flag.StringVar)
+	optionBoost         = defineFlagValue("b", "prominence-boost" /* */, "Boost keywords appearing in h1 or early in the page (frequency algorithm)", false, flag.Bool, flag.BoolVar)
+	optionLang          = defineFlagValue("l", "lang" /*     */, "Page language (en, ja, zh, ko, de, fr, es, it, pt); detected automatically if omitted", "", flag.String, flag.StringVar)
+	optionJaDict        = defineFlagValue("j", "ja-dict" /*  */, "Japanese dictionary (ipa, uni)", "ipa", flag.String, flag.StringVar)
+	optionJaDictFile    = defineFlagValue("f", "ja-dict-file" /* */, "Path to a kagome dictionary file to use instead of the bundled one", "", flag.String, flag.StringVar)
+	optionUserDict      = defineFlagValue("U", "user-dict" /* */, "Path to a kagome user dictionary CSV for Japanese (surface,segmentation,reading,pos)", "", flag.String, flag.StringVar)
+	optionMergeReading  = defineFlagValue("r", "merge-reading" /* */, "Count Japanese keywords with the same reading and kanji as one keyword (e.g. 問合せ / 問い合わせ)", false, flag.Bool, flag.BoolVar)
+	optionSave          = defineFlagValue("s", "save" /*     */, "Save the result to the history database (see the history command)", false, flag.Bool, flag.BoolVar)
+	optionHistoryDB     = defineFlagValue("H", "history-db" /* */, "Path to the history database", history.DefaultPath(), flag.String, flag.StringVar)
+	optionJaFilter      = defineFlagValue("P", "ja-pos" /*   */, "Japanese part-of-speech filter preset (default, proper-nouns, intent)", "default", flag.String, flag.StringVar)
+	optionDisableAudit  = defineFlagSliceValue(flag.CommandLine, "A", "disable-audit", "SEO audit rule to disable (repeatable; "+strings.Join(config.AuditRuleNames, ", ")+")")
 )
 
+// subcommand は "sitekeyword <name> [OPTIONS]" で実行するコマンド
+type subcommand struct {
+	name        string
+	description string
+	run         func(args []string)
+}
+
+var subcommands []subcommand
+
 func init() {
+	subcommands = []subcommand{
+		{"cannibalization", cannibalizationDescription, runCannibalization},
+		{"compare", compareDescription, runCompare},
+		{"history", historyDescription, runHistory},
+		{"diff", diffDescription, runDiff},
+		{"watch", watchDescription, runWatch},
+	}
 	// Customize the usage message
-	flag.Usage = customUsage(commandDescription)
+	flag.Usage = customUsage(flag.CommandLine, "", commandDescription)
 }
 
 // Build:
 // $ GOOS=darwin GOARCH=amd64 go build -ldflags="-s -w" -trimpath ./cmd/sitekeyword
 func main() {
+	if len(os.Args) > 1 {
+		for _, c := range subcommands {
+			if os.Args[1] == c.name {
+				c.run(os.Args[2:])
+				return
+			}
+		}
+	}
 	flag.Parse()
 	if *optionUrl == "" {
 		flag.Usage()
 		os.Exit(0)
 	}
 
-	cfg := config.DefaultConfig()
-	anlz, err := analyzer.NewAnalyzer(*optionUrl, cfg)
+	// Ctrl+C (SIGINT) で取得・解析中の処理をキャンセル
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg := analysisConfig(*optionAlgorithm)
+	requireChoice("normalization", *optionNormalization, "max", "sum", "softmax")
+	cfg.Normalization = *optionNormalization
+	cfg.ProminenceBoost = *optionBoost
+	switch *optionLang {
+	case "", "en", "ja", "zh", "ko", "de", "fr", "es", "it", "pt":
+		cfg.Language = *optionLang
+	default:
+		handleError(fmt.Errorf("unsupported language '%s'", *optionLang), "Options")
+		os.Exit(1)
+	}
+	requireChoice("Japanese dictionary", *optionJaDict, "ipa", "uni")
+	cfg.JapaneseDictionary = *optionJaDict
+	cfg.JapaneseDictionaryFile = *optionJaDictFile
+	cfg.JapaneseUserDictFile = *optionUserDict
+	jaFilter, err := config.JapaneseFilterPreset(*optionJaFilter, cfg.JapaneseDictionary)
 	if err != nil {
-		handleError(err, "NewAnalyzer")
+		handleError(err, "Options")
+		os.Exit(1)
+	}
+	cfg.JapaneseFilter = jaFilter
+	cfg.JapaneseMergeByReading = *optionMergeReading
+	if err := cfg.Audit.Disable(*optionDisableAudit...); err != nil {
+		handleError(err, "Options")
 		os.Exit(1)
 	}
+	anlz, err := analyzer.NewAnalyzerContext(ctx, *optionUrl, cfg)
+	if err != nil {
+		handleError(err, "NewAnalyzer")
+		exit(err)
+	}
 	// 解析結果を取得
-	result, err := anlz.GetAnalysisResult(20)
+	result, err := anlz.GetAnalysisResultContext(ctx, 20)
 	if err != nil {
 		handleError(err, "GetAnalysisResult")
-		os.Exit(1)
+		exit(err)
+	}
+
+	if *optionSave {
+		if err := saveSnapshot(*optionHistoryDB, *optionUrl, result); err != nil {
+			handleError(err, "SaveHistory")
+			os.Exit(1)
+		}
 	}
 
 	// JSON形式で出力
-	var jsonData []byte
 	var outputObj interface{}
 
 	// 詳細表示フラグに応じて出力形式を切り替え
@@ -65,7 +141,10 @@ func main() {
 		// 詳細表示：全てのフィールドを出力
 		outputObj = result
 	} else {
-		// デフォルト：keywordsのみを出力（匿名構造体を使用）
+		// デフォルト：keywordsのみを出力（匿名構造体を使用、出現位置などの詳細は除く）
+		for i := range result.Keywords {
+			result.Keywords[i].Prominence = nil
+		}
 		outputObj = struct {
 			Keywords interface{} `json:"keywords"`
 		}{
@@ -73,14 +152,20 @@ func main() {
 		}
 	}
 
-	if *optionPretty {
+	printJSON(outputObj, *optionPretty)
+}
+
+// printJSON は v をJSONで出力します（pretty ならインデント付き）
+func printJSON(v interface{}, pretty bool) {
+	var jsonData []byte
+	var err error
+	if pretty {
 		// インデント付きのJSON出力（pretty形式）
-		jsonData, err = json.MarshalIndent(outputObj, "", "  ")
+		jsonData, err = json.MarshalIndent(v, "", "  ")
 	} else {
 		// 1行のJSON出力
-		jsonData, err = json.Marshal(outputObj)
+		jsonData, err = json.Marshal(v)
 	}
-
 	if err != nil {
 		handleError(err, "JSON Marshal")
 		os.Exit(1)
@@ -98,6 +183,30 @@ func handleError(err error, prefixErrMessage string) {
 	}
 }
 
+// requireChoice は value が choices のいずれでもなければエラーを出力して終了します（name はエラーメッセージに使うオプションの説明）
+func requireChoice(name, value string, choices ...string) {
+	if !slices.Contains(choices, value) {
+		handleError(fmt.Errorf("unknown %s '%s'", name, value), "Options")
+		os.Exit(1)
+	}
+}
+
+// analysisConfig は -a, --algorithm の値を確認し、そのアルゴリズムを設定した既定の Config を返します
+func analysisConfig(algorithm string) config.Config {
+	requireChoice("algorithm", algorithm, "frequency", "textrank", "yake")
+	cfg := config.DefaultConfig()
+	cfg.Algorithm = algorithm
+	return cfg
+}
+
+// exit はキャンセルによる終了なら 130 (SIGINT慣例)、それ以外は 1 で終了します
+func exit(err error) {
+	if errors.Is(err, context.Canceled) {
+		os.Exit(130)
+	}
+	os.Exit(1)
+}
+
 // =======================================
 // flag Utils
 // =======================================
@@ -114,29 +223,73 @@ func defineFlagValue[T comparable](short, long, description string, defaultValue
 	return f
 }
 
-// Custom usage message
-func customUsage(description string) func() {
+// stringsValue は繰り返し指定できる文字列のオプション（-u a -u b）
+type stringsValue []string
+
+func (v *stringsValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *stringsValue) Set(value string) error {
+	*v = append(*v, value)
+	return nil
+}
+
+// Helper function for repeatable flag
+func defineFlagSliceValue(fs *flag.FlagSet, short, long, description string) *[]string {
+	v := &stringsValue{}
+	fs.Var(v, long, short+UsageDummy+description)
+	fs.Var(v, short, UsageDummy)
+	return (*[]string)(v)
+}
+
+// parseArgs はオプションと位置引数が混在していても解析し、位置引数を返します（diff OLD NEW -e など）
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			return positional
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// Custom usage message (command is empty for the root command)
+func customUsage(fs *flag.FlagSet, command, description string) func() {
 	return func() {
-		optionsUsage, requiredOptionExample := getOptionsUsage(false)
-		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s %s[OPTIONS]\n\n", func() string { e, _ := os.Executable(); return filepath.Base(e) }(), requiredOptionExample)
-		fmt.Fprintf(flag.CommandLine.Output(), "Description:\n  %s\n\n", description)
-		fmt.Fprintf(flag.CommandLine.Output(), "Options:\n%s", optionsUsage)
+		optionsUsage, requiredOptionExample := getOptionsUsage(fs, false)
+		name := func() string { e, _ := os.Executable(); return filepath.Base(e) }()
+		if command != "" {
+			name += " " + command
+		}
+		fmt.Fprintf(fs.Output(), "Usage: %s %s[OPTIONS]\n\n", name, requiredOptionExample)
+		fmt.Fprintf(fs.Output(), "Description:\n  %s\n\n", description)
+		if command == "" {
+			fmt.Fprintf(fs.Output(), "Commands:\n")
+			for _, c := range subcommands {
+				fmt.Fprintf(fs.Output(), "  %-16s %s\n", c.name, c.description)
+			}
+			fmt.Fprintf(fs.Output(), "\n")
+		}
+		fmt.Fprintf(fs.Output(), "Options:\n%s", optionsUsage)
 	}
 }
 
 // Get options usage message
-func getOptionsUsage(currentValue bool) (string, string) {
+func getOptionsUsage(fs *flag.FlagSet, currentValue bool) (string, string) {
 	requiredOptionExample := ""
 	optionNameWidth := 0
 	usages := make([]string, 0)
 	getType := func(v string) string {
-		return strings.NewReplacer("*flag.boolValue", "", "*flag.", "<", "Value", ">").Replace(v)
+		return strings.NewReplacer("*flag.boolValue", "", "*main.stringsValue", "<string>", "*flag.", "<", "Value", ">").Replace(v)
 		//return strings.NewReplacer("*flag.boolValue", "", "*flag.", "", "Value", "").Replace(v)
 	}
-	flag.VisitAll(func(f *flag.Flag) {
+	fs.VisitAll(func(f *flag.Flag) {
 		optionNameWidth = max(optionNameWidth, len(fmt.Sprintf("%s %s", f.Name, getType(fmt.Sprintf("%T", f.Value))))+4)
 	})
-	flag.VisitAll(func(f *flag.Flag) {
+	fs.VisitAll(func(f *flag.Flag) {
 		if f.Usage == UsageDummy {
 			return
 		}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/history"
+	"github.com/xshoji/go-site-keyword/pkg/report"
+	"github.com/xshoji/go-site-keyword/pkg/watch"
+)
+
+const watchDescription = "Re-analyze pages periodically and POST a JSON alert to a webhook when the title, meta description or top keywords change."
+
+// runWatch はページを定期的に解析し、変化を Webhook に通知します
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	fs.Usage = customUsage(fs, "watch", watchDescription)
+	optionUrls := defineFlagSliceValue(fs, "u", "url", "URL to watch (repeatable)")
+	optionUrlsFile := defineFlagValue("i", "urls-file" /*  */, "File with one URL per line", "", fs.String, fs.StringVar)
+	optionInterval := defineFlagValue("I", "interval" /*   */, "Interval between checks (e.g. 30m, 6h)", time.Hour, fs.Duration, fs.DurationVar)
+	optionWebhook := defineFlagValue("w", "webhook" /*    */, "Webhook URL to POST alerts to (alerts are only printed if omitted)", "", fs.String, fs.StringVar)
+	optionTop := defineFlagValue("t", "top" /*        */, "Number of top keywords compared", watch.DefaultTopN, fs.Int, fs.IntVar)
+	optionMinDelta := defineFlagValue("m", "min-delta" /*  */, "Normalized score change of a top keyword (or score of a keyword entering or leaving the top) that triggers an alert", watch.DefaultMinScoreDelta, fs.Float64, fs.Float64Var)
+	optionOnce := defineFlagValue("o", "once" /*       */, "Check once and exit (e.g. from cron; use with --save)", false, fs.Bool, fs.BoolVar)
+	optionSave := defineFlagValue("s", "save" /*       */, "Save results to the history database and compare with the last saved result", false, fs.Bool, fs.BoolVar)
+	optionHistoryDB := defineFlagValue("H", "history-db" /* */, "Path to the history database", history.DefaultPath(), fs.String, fs.StringVar)
+	optionAlgorithm := defineFlagValue("a", "algorithm" /*  */, "Keyword scoring algorithm (frequency, textrank, yake)", "frequency", fs.String, fs.StringVar)
+	fs.Parse(args)
+
+	urls := append([]string{}, *optionUrls...)
+	if *optionUrlsFile != "" {
+		lines, err := readURLsFile(*optionUrlsFile)
+		if err != nil {
+			handleError(err, "Options")
+			os.Exit(1)
+		}
+		urls = append(urls, lines...)
+	}
+	if len(urls) == 0 {
+		fs.Usage()
+		os.Exit(0)
+	}
+	if *optionInterval <= 0 {
+		handleError(fmt.Errorf("interval must be positive: %v", *optionInterval), "Options")
+		os.Exit(1)
+	}
+	cfg := analysisConfig(*optionAlgorithm)
+	opts := watch.Options{
+		URLs:            urls,
+		Interval:        *optionInterval,
+		WebhookURL:      *optionWebhook,
+		TopN:            *optionTop,
+		MinScoreDelta:   *optionMinDelta,
+		AnalyzerOptions: []analyzer.Option{analyzer.WithConfig(cfg)},
+	}
+	if *optionSave {
+		store, err := history.Open(*optionHistoryDB)
+		if err != nil {
+			handleError(err, "OpenHistory")
+			os.Exit(1)
+		}
+		defer store.Close()
+		opts.Store = store
+	}
+
+	// Ctrl+C (SIGINT) で監視を終了
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	w := watch.New(opts)
+	printCheck := func(alerts []watch.Alert, pageErrors []report.PageError) {
+		for _, e := range pageErrors {
+			handleError(errors.New(e.Error), "Watch "+e.URL)
+		}
+		for _, alert := range alerts {
+			printJSON(alert, false)
+		}
+	}
+	if *optionOnce {
+		alerts, pageErrors, err := w.CheckContext(ctx)
+		if err != nil {
+			handleError(err, "Watch")
+			exit(err)
+		}
+		printCheck(alerts, pageErrors)
+		return
+	}
+	if err := w.RunContext(ctx, printCheck); err != nil && !errors.Is(err, context.Canceled) {
+		handleError(err, "Watch")
+		os.Exit(1)
+	}
+}
@@ -6,12 +6,19 @@ toolchain go1.23.2
 
 require (
 	github.com/PuerkitoBio/goquery v1.8.1
+	github.com/andybalholm/brotli v1.1.1
+	github.com/blevesearch/snowballstem v0.9.0
+	github.com/ikawaha/kagome-dict v1.0.9
+	github.com/ikawaha/kagome-dict-ko v0.2.1
 	github.com/ikawaha/kagome-dict/ipa v1.0.10
+	github.com/ikawaha/kagome-dict/uni v1.1.9
 	github.com/ikawaha/kagome/v2 v2.9.3
+	go.etcd.io/bbolt v1.3.10
+	golang.org/x/net v0.39.0
+	golang.org/x/text v0.24.0
 )
 
 require (
 	github.com/andybalholm/cascadia v1.3.3 // indirect
-	github.com/ikawaha/kagome-dict v1.0.9 // indirect
-	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
 )
@@ -0,0 +1,228 @@
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xshoji/go-site-keyword/internal/language"
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// maxListedImages は image-alt の指摘に列挙する画像の数
+const maxListedImages = 5
+
+// Page は監査に使うページの情報
+type Page struct {
+	URL         string
+	Title       string
+	Description string
+	// H1 は h1 のテキスト（空の h1 は除く）
+	H1 []string
+	// ImagesWithoutAlt は alt 属性のない img の src
+	ImagesWithoutAlt []string
+	// Robots は meta robots・X-Robots-Tag の指示（小文字）
+	Robots []string
+	// Keywords は上位のキーワードと出現回数（順位順）
+	Keywords []Keyword
+	// TotalCount はページ内のキーワード候補の出現回数の合計
+	TotalCount int
+	// Keys はテキストをキーワードの集計キー（正規化・語幹・読みなど）の並びに分割します
+	// keyword-placement はキーの並びで照合し、nil なら正規化したテキストの包含で照合します
+	Keys func(text string) []string
+}
+
+// Keyword はキーワードとページ内での出現回数
+type Keyword struct {
+	Keyword string
+	Count   int
+}
+
+// rule は監査ルールの名前・設定・確認処理（問題ごとのメッセージを返す）
+type rule struct {
+	name    string
+	setting config.AuditRule
+	check   func(page Page, cfg config.AuditConfig) []string
+}
+
+// Run は cfg で有効なルールでページを監査し、見つかった問題をルール順に返します
+func Run(page Page, cfg config.AuditConfig) []types.Finding {
+	rules := []rule{
+		{config.AuditTitleLength, cfg.TitleLength.AuditRule, checkTitleLength},
+		{config.AuditDescriptionLength, cfg.DescriptionLength.AuditRule, checkDescriptionLength},
+		{config.AuditMissingDescription, cfg.MissingDescription, checkMissingDescription},
+		{config.AuditMissingH1, cfg.MissingH1, checkMissingH1},
+		{config.AuditMultipleH1, cfg.MultipleH1, checkMultipleH1},
+		{config.AuditKeywordPlacement, cfg.KeywordPlacement, checkKeywordPlacement},
+		{config.AuditKeywordStuffing, cfg.KeywordStuffing.AuditRule, checkKeywordStuffing},
+		{config.AuditImageAlt, cfg.ImageAlt, checkImageAlt},
+		{config.AuditNoindex, cfg.Noindex, checkNoindex},
+	}
+	var findings []types.Finding
+	for _, r := range rules {
+		if !r.setting.Enabled {
+			continue
+		}
+		severity := r.setting.Severity
+		if severity == "" {
+			severity = types.SeverityWarning
+		}
+		for _, msg := range r.check(page, cfg) {
+			findings = append(findings, types.Finding{Rule: r.name, Severity: severity, Message: msg})
+		}
+	}
+	return findings
+}
+
+func checkTitleLength(page Page, cfg config.AuditConfig) []string {
+	title := strings.TrimSpace(page.Title)
+	if title == "" {
+		return []string{"title is missing"}
+	}
+	return checkLength("title", title, cfg.TitleLength)
+}
+
+func checkDescriptionLength(page Page, cfg config.AuditConfig) []string {
+	description := strings.TrimSpace(page.Description)
+	if description == "" {
+		return nil
+	}
+	return checkLength("meta description", description, cfg.DescriptionLength)
+}
+
+// checkLength は text の文字数が Min〜Max の範囲外ならメッセージを返します
+func checkLength(name, text string, r config.AuditLengthRule) []string {
+	n := utf8.RuneCountInString(text)
+	if (r.Min > 0 && n < r.Min) || (r.Max > 0 && n > r.Max) {
+		return []string{fmt.Sprintf("%s is %d characters (recommended %s)", name, n, lengthRange(r))}
+	}
+	return nil
+}
+
+func lengthRange(r config.AuditLengthRule) string {
+	switch {
+	case r.Max <= 0:
+		return fmt.Sprintf("at least %d", r.Min)
+	case r.Min <= 0:
+		return fmt.Sprintf("at most %d", r.Max)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+func checkMissingDescription(page Page, cfg config.AuditConfig) []string {
+	if strings.TrimSpace(page.Description) == "" {
+		return []string{"meta description is missing"}
+	}
+	return nil
+}
+
+func checkMissingH1(page Page, cfg config.AuditConfig) []string {
+	if len(page.H1) == 0 {
+		return []string{"h1 is missing"}
+	}
+	return nil
+}
+
+func checkMultipleH1(page Page, cfg config.AuditConfig) []string {
+	if len(page.H1) > 1 {
+		return []string{fmt.Sprintf("page has %d h1 elements", len(page.H1))}
+	}
+	return nil
+}
+
+func checkKeywordPlacement(page Page, cfg config.AuditConfig) []string {
+	if len(page.Keywords) == 0 {
+		return nil
+	}
+	targets := append([]string{page.Title, urlText(page.URL)}, page.H1...)
+	for _, t := range targets {
+		if containsKeyword(page, t, page.Keywords[0].Keyword) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("top keyword '%s' does not appear in title, h1 or URL", page.Keywords[0].Keyword)}
+}
+
+func checkKeywordStuffing(page Page, cfg config.AuditConfig) []string {
+	r := cfg.KeywordStuffing
+	if page.TotalCount == 0 || r.MaxDensity <= 0 {
+		return nil
+	}
+	var msgs []string
+	for _, kw := range page.Keywords {
+		if kw.Count < r.MinCount {
+			continue
+		}
+		if density := float64(kw.Count) / float64(page.TotalCount); density > r.MaxDensity {
+			msgs = append(msgs, fmt.Sprintf("keyword '%s' makes up %.1f%% of keyword occurrences (%d of %d, max %.1f%%)",
+				kw.Keyword, density*100, kw.Count, page.TotalCount, r.MaxDensity*100))
+		}
+	}
+	return msgs
+}
+
+func checkImageAlt(page Page, cfg config.AuditConfig) []string {
+	n := len(page.ImagesWithoutAlt)
+	if n == 0 {
+		return nil
+	}
+	listed := page.ImagesWithoutAlt
+	if n > maxListedImages {
+		listed = listed[:maxListedImages]
+	}
+	msg := fmt.Sprintf("%d image(s) have no alt attribute: %s", n, strings.Join(listed, ", "))
+	if n > maxListedImages {
+		msg += ", ..."
+	}
+	return []string{msg}
+}
+
+func checkNoindex(page Page, cfg config.AuditConfig) []string {
+	for _, d := range page.Robots {
+		if d == "noindex" || d == "none" {
+			return []string{fmt.Sprintf("page is excluded from search results by robots directive '%s'", d)}
+		}
+	}
+	return nil
+}
+
+// containsKeyword は text に keyword が含まれるか判定します
+// page.Keys があれば集計キーの並びが連続して現れるかで判定し（"Shoes" と "shoe"、"おすすめ" と "オススメ" も一致）、
+// キーが得られない場合は正規化したテキストの包含で判定します
+func containsKeyword(page Page, text, keyword string) bool {
+	if page.Keys != nil {
+		if keys := page.Keys(keyword); len(keys) > 0 {
+			return containsSequence(page.Keys(text), keys)
+		}
+	}
+	return strings.Contains(matchKey(text), matchKey(keyword))
+}
+
+// containsSequence は keys が sub を連続した部分列として含むか判定します
+func containsSequence(keys, sub []string) bool {
+	for i := 0; i+len(sub) <= len(keys); i++ {
+		if slices.Equal(keys[i:i+len(sub)], sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKey は包含判定用に NFKC 正規化・小文字化・空白の統一を行います
+func matchKey(text string) string {
+	text = strings.ToLower(language.NormalizeString(text, language.NormalizeOptions{}))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// urlText は URL のホストとパスを区切り文字（- _ / . +）を空白にしたテキストにします（"/running-shoes" → "running shoes"）
+func urlText(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	text := u.Host + " " + u.Path
+	return strings.NewReplacer("-", " ", "_", " ", "/", " ", ".", " ", "+", " ").Replace(text)
+}
@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// goodPage はどのルールにも該当しないページ
+func goodPage() Page {
+	return Page{
+		URL:         "https://example.com/running-shoes",
+		Title:       "Running Shoes for Trail and Road | Example Store",
+		Description: "Compare lightweight running shoes for trail and road, with cushioning, fit and durability notes for every budget.",
+		H1:          []string{"Running shoes"},
+		Keywords:    []Keyword{{Keyword: "running shoes", Count: 6}, {Keyword: "trail", Count: 4}},
+		TotalCount:  120,
+	}
+}
+
+func rules(findings []types.Finding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, f.Rule)
+	}
+	return result
+}
+
+func TestRunNoFindings(t *testing.T) {
+	if findings := Run(goodPage(), config.DefaultAuditConfig()); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
+
+func TestRunFindings(t *testing.T) {
+	page := Page{
+		URL:              "https://example.com/p?id=1",
+		Title:            "Shop",
+		H1:               []string{"Welcome", "Sale"},
+		ImagesWithoutAlt: []string{"a.jpg", "b.jpg"},
+		Robots:           []string{"noindex", "follow"},
+		Keywords:         []Keyword{{Keyword: "Sneakers", Count: 30}, {Keyword: "sale", Count: 3}},
+		TotalCount:       100,
+	}
+	findings := Run(page, config.DefaultAuditConfig())
+	expected := []string{
+		config.AuditTitleLength, config.AuditMissingDescription, config.AuditMultipleH1,
+		config.AuditKeywordPlacement, config.AuditKeywordStuffing, config.AuditImageAlt, config.AuditNoindex,
+	}
+	if strings.Join(rules(findings), ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected rules %v, got %v", expected, findings)
+	}
+	bySeverity := map[string]string{}
+	for _, f := range findings {
+		bySeverity[f.Rule] = f.Severity
+	}
+	if bySeverity[config.AuditNoindex] != types.SeverityError || bySeverity[config.AuditImageAlt] != types.SeverityInfo {
+		t.Errorf("unexpected severities: %v", bySeverity)
+	}
+	if !strings.Contains(findings[0].Message, "4 characters (recommended 30-60)") {
+		t.Errorf("unexpected title message: %s", findings[0].Message)
+	}
+	if !strings.Contains(findings[4].Message, "'Sneakers' makes up 30.0%") {
+		t.Errorf("unexpected stuffing message: %s", findings[4].Message)
+	}
+}
+
+func TestRunMissingTitleAndH1(t *testing.T) {
+	page := goodPage()
+	page.Title = " "
+	page.H1 = nil
+	page.Description = strings.Repeat("a", 200)
+	findings := Run(page, config.DefaultAuditConfig())
+	expected := []string{config.AuditTitleLength, config.AuditDescriptionLength, config.AuditMissingH1}
+	if strings.Join(rules(findings), ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected rules %v, got %v", expected, findings)
+	}
+	if findings[0].Message != "title is missing" {
+		t.Errorf("expected 'title is missing', got %q", findings[0].Message)
+	}
+}
+
+func TestKeywordPlacement(t *testing.T) {
+	cases := []struct {
+		name    string
+		keyword string
+		page    Page
+		absent  bool
+	}{
+		{"title", "Running Shoes", Page{Title: "ＲＵＮＮＩＮＧ Shoes"}, false},
+		{"h1", "Running Shoes", Page{H1: []string{"Best running  shoes"}}, false},
+		{"url", "Running Shoes", Page{URL: "https://example.com/running_shoes/"}, false},
+		{"japanese url", "ランニング", Page{URL: "https://example.com/%E3%83%A9%E3%83%B3%E3%83%8B%E3%83%B3%E3%82%B0"}, false},
+		{"absent", "Running Shoes", Page{Title: "Trail guide", URL: "https://example.com/guide"}, true},
+	}
+	for _, c := range cases {
+		c.page.Keywords = []Keyword{{Keyword: c.keyword}}
+		got := checkKeywordPlacement(c.page, config.DefaultAuditConfig())
+		if (len(got) > 0) != c.absent {
+			t.Errorf("%s: expected finding=%v, got %v", c.name, c.absent, got)
+		}
+	}
+}
+
+func TestKeywordPlacement_Keys(t *testing.T) {
+	// 語幹をキーとする分割（テスト用に末尾の s を除く）
+	keys := func(text string) []string {
+		var result []string
+		for _, w := range strings.Fields(strings.ToLower(text)) {
+			result = append(result, strings.TrimSuffix(w, "s"))
+		}
+		return result
+	}
+	cases := []struct {
+		name    string
+		keyword string
+		page    Page
+		absent  bool
+	}{
+		{"stem", "Shoes", Page{Title: "Running shoe guide"}, false},
+		{"phrase", "running shoes", Page{H1: []string{"Best running shoe"}}, false},
+		{"url", "shoes", Page{URL: "https://example.com/trail-shoe"}, false},
+		// 表記の部分一致では一致としない
+		{"substring", "shoe", Page{Title: "Shoelaces"}, true},
+		{"order", "running shoes", Page{Title: "Shoes for running"}, true},
+	}
+	for _, c := range cases {
+		c.page.Keys = keys
+		c.page.Keywords = []Keyword{{Keyword: c.keyword}}
+		got := checkKeywordPlacement(c.page, config.DefaultAuditConfig())
+		if (len(got) > 0) != c.absent {
+			t.Errorf("%s: expected finding=%v, got %v", c.name, c.absent, got)
+		}
+	}
+}
+
+func TestRunConfigurable(t *testing.T) {
+	page := goodPage()
+	page.Title = "Short"
+	page.H1 = nil
+
+	cfg := config.DefaultAuditConfig()
+	if err := cfg.Disable(config.AuditMissingH1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.TitleLength.Min = 3
+	if findings := Run(page, cfg); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+
+	cfg = config.DefaultAuditConfig()
+	cfg.MissingH1.Severity = types.SeverityError
+	cfg.TitleLength.Severity = ""
+	findings := Run(page, cfg)
+	if len(findings) != 2 || findings[0].Severity != types.SeverityWarning || findings[1].Severity != types.SeverityError {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+
+	if findings := Run(page, config.AuditConfig{}); len(findings) != 0 {
+		t.Errorf("expected zero-value config to disable all rules, got %v", findings)
+	}
+}
+
+func TestImageAltListsFirstImages(t *testing.T) {
+	page := Page{ImagesWithoutAlt: []string{"1.jpg", "2.jpg", "3.jpg", "4.jpg", "5.jpg", "6.jpg"}}
+	got := checkImageAlt(page, config.DefaultAuditConfig())
+	if len(got) != 1 || got[0] != "6 image(s) have no alt attribute: 1.jpg, 2.jpg, 3.jpg, 4.jpg, 5.jpg, ..." {
+		t.Errorf("unexpected message: %v", got)
+	}
+}
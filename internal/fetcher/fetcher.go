@@ -1,54 +1,232 @@
 package fetcher
 
 import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/andybalholm/brotli"
 )
 
 const UserAgent = "Mozilla/5.0 (compatible; KeywordBot/1.0)"
 
+// DefaultTimeoutSeconds はデフォルトのタイムアウト秒数
+const DefaultTimeoutSeconds = 10
+
+// DefaultMaxBodySize はレスポンスボディの最大サイズ（展開後, 5MB）
+const DefaultMaxBodySize int64 = 5 * 1024 * 1024
+
+// DefaultAllowedContentTypes は解析対象として許可するContent-Type
+var DefaultAllowedContentTypes = []string{"text/html", "application/xhtml+xml"}
+
 // FetchResult はHTTP取得結果を格納します
 // （今後の拡張用に構造体でラップ）
 type FetchResult struct {
-	URL  string
-	Body []byte
+	URL         string
+	Body        []byte
+	ContentType string
+	// Truncated はボディが MaxBodySize で切り詰められた場合に true
+	Truncated bool
+	// ContentLanguage は Content-Language ヘッダーの値（言語判定の手がかり）
+	ContentLanguage string
+	// XRobotsTag は X-Robots-Tag ヘッダーの値（複数ある場合はカンマ区切りで連結）
+	XRobotsTag string
+}
+
+// Options は FetchURLWithOptions の取得設定です
+type Options struct {
+	TimeoutSeconds int
+	UserAgent      string
+	// MaxBodySize は展開後ボディの上限バイト数（0以下で無制限）
+	MaxBodySize int64
+	// TruncateLargeBody が true なら上限を超えた分を切り捨て、false なら BodyTooLargeError を返す
+	TruncateLargeBody bool
+	// AllowedContentTypes は許可するメディアタイプ（空なら全て許可）
+	AllowedContentTypes []string
+	// Client を指定するとそのクライアントで取得する（nilなら TimeoutSeconds から生成）
+	Client *http.Client
+}
+
+// DefaultOptions はデフォルトの取得設定を返します
+func DefaultOptions(timeoutSeconds int) Options {
+	return Options{
+		TimeoutSeconds:      timeoutSeconds,
+		UserAgent:           UserAgent,
+		MaxBodySize:         DefaultMaxBodySize,
+		TruncateLargeBody:   true,
+		AllowedContentTypes: DefaultAllowedContentTypes,
+	}
+}
+
+// UnsupportedContentTypeError は許可されていないContent-Typeのレスポンスを表します
+type UnsupportedContentTypeError struct {
+	URL         string
+	ContentType string
+}
+
+func (e *UnsupportedContentTypeError) Error() string {
+	return fmt.Sprintf("Unsupported content type '%s' for URL '%s'", e.ContentType, e.URL)
+}
+
+// BodyTooLargeError はレスポンスボディが上限を超えたことを表します
+type BodyTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("Response body from URL '%s' exceeds the limit of %d bytes", e.URL, e.Limit)
 }
 
 // FetchURL は指定URLからHTTPレスポンスボディを取得します
 func FetchURL(url string, timeoutSeconds int) (*FetchResult, error) {
-	client := &http.Client{
-		Timeout: time.Duration(timeoutSeconds) * time.Second,
-		Transport: &http.Transport{
-			MaxIdleConns:       10,
-			IdleConnTimeout:    30 * time.Second,
-			DisableCompression: true,
-		},
+	return FetchURLWithOptions(url, DefaultOptions(timeoutSeconds))
+}
+
+// FetchURLWithOptions は取得設定を指定してHTTPレスポンスボディを取得します
+func FetchURLWithOptions(url string, opts Options) (*FetchResult, error) {
+	return FetchURLContext(context.Background(), url, opts)
+}
+
+// FetchURLContext は ctx のキャンセル・期限に従ってHTTPレスポンスボディを取得します
+// キャンセル時のエラーは context.Canceled / context.DeadlineExceeded をラップします
+func FetchURLContext(ctx context.Context, url string, opts Options) (*FetchResult, error) {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{
+			Timeout: time.Duration(opts.TimeoutSeconds) * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConns:    10,
+				IdleConnTimeout: 30 * time.Second,
+				// 展開は decodeBody で行う（brotli も扱うため）
+				DisableCompression: true,
+			},
+		}
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create HTTP request for URL '%s': %w", url, err)
 	}
 
-	req.Header.Set("User-Agent", UserAgent)
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = UserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
 
 	resp, err := client.Do(req)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("Failed to access URL '%s': %w", url, ctx.Err())
+		}
 		return nil, fmt.Errorf("Failed to access URL '%s': %w", url, err)
 	}
 	defer resp.Body.Close()
 
 	finalURL := resp.Request.URL.String()
 
-	body, err := io.ReadAll(resp.Body)
+	contentType := resp.Header.Get("Content-Type")
+	if !isAllowedContentType(contentType, opts.AllowedContentTypes) {
+		return nil, &UnsupportedContentTypeError{URL: finalURL, ContentType: contentType}
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	// 非圧縮でContent-Lengthが上限を超えていれば読む前に中断
+	if opts.MaxBodySize > 0 && !opts.TruncateLargeBody && (encoding == "" || encoding == "identity") && resp.ContentLength > opts.MaxBodySize {
+		return nil, &BodyTooLargeError{URL: finalURL, Limit: opts.MaxBodySize}
+	}
+
+	reader, err := decodeBody(resp.Body, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode response body from URL '%s': %w", finalURL, err)
+	}
+	defer reader.Close()
+
+	body, truncated, err := readLimited(reader, opts.MaxBodySize)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("Failed to read response body from URL '%s': %w", finalURL, ctx.Err())
+		}
 		return nil, fmt.Errorf("Failed to read response body from URL '%s': %w", finalURL, err)
 	}
+	if truncated && !opts.TruncateLargeBody {
+		return nil, &BodyTooLargeError{URL: finalURL, Limit: opts.MaxBodySize}
+	}
 
 	return &FetchResult{
-		URL:  finalURL,
-		Body: body,
+		URL:             finalURL,
+		Body:            body,
+		ContentType:     contentType,
+		Truncated:       truncated,
+		ContentLanguage: resp.Header.Get("Content-Language"),
+		XRobotsTag:      strings.Join(resp.Header.Values("X-Robots-Tag"), ", "),
 	}, nil
 }
+
+// isAllowedContentType はContent-Typeが許可リストに含まれるか判定（ヘッダーなしは許可）
+func isAllowedContentType(contentType string, allowed []string) bool {
+	if len(allowed) == 0 || contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(mediaType, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBody はContent-Encodingに応じてボディを展開するReaderを返します
+func decodeBody(body io.Reader, encoding string) (io.ReadCloser, error) {
+	switch encoding {
+	case "", "identity":
+		return io.NopCloser(body), nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		// HTTPのdeflateは本来zlib形式だが、生のdeflateを返すサーバーもある
+		buffered := bufio.NewReader(body)
+		header, err := buffered.Peek(2)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if len(header) == 2 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 && header[0]&0x0f == 8 {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	case "br":
+		return io.NopCloser(brotli.NewReader(body)), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding '%s'", encoding)
+	}
+}
+
+// readLimited は最大 limit バイトまで読み込み、超過した場合は truncated=true を返します
+func readLimited(r io.Reader, limit int64) ([]byte, bool, error) {
+	if limit <= 0 {
+		body, err := io.ReadAll(r)
+		return body, false, err
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(body)) > limit {
+		return body[:limit], true, nil
+	}
+	return body, false, nil
+}
@@ -1,9 +1,20 @@
 package fetcher
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
 )
 
 func TestFetchURL_Success(t *testing.T) {
@@ -24,6 +35,39 @@ func TestFetchURL_Success(t *testing.T) {
 	}
 }
 
+func TestFetchURL_XRobotsTag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Robots-Tag", "noindex")
+		w.Header().Add("X-Robots-Tag", "nofollow")
+		w.Write([]byte("<html><body>Hello</body></html>"))
+	}))
+	defer ts.Close()
+
+	res, err := FetchURL(ts.URL, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.XRobotsTag != "noindex, nofollow" {
+		t.Errorf("expected X-Robots-Tag 'noindex, nofollow', got %q", res.XRobotsTag)
+	}
+}
+
+func TestFetchURL_ContentLanguage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Language", "de-DE")
+		w.Write([]byte("<html><body>Hallo</body></html>"))
+	}))
+	defer ts.Close()
+
+	res, err := FetchURL(ts.URL, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.ContentLanguage != "de-DE" {
+		t.Errorf("expected Content-Language de-DE, got %q", res.ContentLanguage)
+	}
+}
+
 func TestFetchURL_Timeout(t *testing.T) {
 	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// no response, simulate timeout
@@ -36,3 +80,115 @@ func TestFetchURL_Timeout(t *testing.T) {
 		t.Error("expected timeout error, got nil")
 	}
 }
+
+func TestFetchURLWithOptions_Gzip(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte("<html><body>Gzip</body></html>"))
+		gz.Close()
+	}))
+	defer ts.Close()
+
+	res, err := FetchURLWithOptions(ts.URL, DefaultOptions(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(res.Body) != "<html><body>Gzip</body></html>" {
+		t.Errorf("unexpected body: %s", string(res.Body))
+	}
+}
+
+func TestFetchURLWithOptions_ContentEncoding(t *testing.T) {
+	const html = "<html><body>Compressed</body></html>"
+	cases := []struct {
+		name     string
+		encoding string
+		compress func(w io.Writer) io.WriteCloser
+	}{
+		{"brotli", "br", func(w io.Writer) io.WriteCloser { return brotli.NewWriter(w) }},
+		{"zlib deflate", "deflate", func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }},
+		{"raw deflate", "deflate", func(w io.Writer) io.WriteCloser {
+			fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return fw
+		}},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		cw := c.compress(&buf)
+		cw.Write([]byte(html))
+		cw.Close()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Content-Encoding", c.encoding)
+			w.Write(buf.Bytes())
+		}))
+
+		res, err := FetchURLWithOptions(ts.URL, DefaultOptions(2))
+		ts.Close()
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", c.name, err)
+			continue
+		}
+		if string(res.Body) != html {
+			t.Errorf("%s: unexpected body: %q", c.name, string(res.Body))
+		}
+	}
+}
+
+func TestFetchURLWithOptions_UnsupportedContentType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer ts.Close()
+
+	_, err := FetchURLWithOptions(ts.URL, DefaultOptions(2))
+	var ctErr *UnsupportedContentTypeError
+	if !errors.As(err, &ctErr) {
+		t.Fatalf("expected UnsupportedContentTypeError, got %v", err)
+	}
+	if ctErr.ContentType != "application/pdf" {
+		t.Errorf("expected application/pdf, got %s", ctErr.ContentType)
+	}
+}
+
+func TestFetchURLWithOptions_MaxBodySize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer ts.Close()
+
+	opts := DefaultOptions(2)
+	opts.MaxBodySize = 10
+	res, err := FetchURLWithOptions(ts.URL, opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(res.Body) != 10 || !res.Truncated {
+		t.Errorf("expected truncated body of 10 bytes, got %d (truncated=%v)", len(res.Body), res.Truncated)
+	}
+
+	opts.TruncateLargeBody = false
+	_, err = FetchURLWithOptions(ts.URL, opts)
+	var sizeErr *BodyTooLargeError
+	if !errors.As(err, &sizeErr) {
+		t.Errorf("expected BodyTooLargeError, got %v", err)
+	}
+}
+
+func TestFetchURLContext_Canceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := FetchURLContext(ctx, ts.URL, DefaultOptions(5))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package chinese
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dict.txt は jieba の辞書（約35万語）に IT 用語を追加したもの
+//
+//go:embed dict.txt
+var dictData string
+
+// maxUnknownRun は辞書にない漢字の並びを1語にまとめる最大の文字数（人名の長さ）
+// これより長い並びは1文字ずつに分けます（フレーズ全体が1語になるのを防ぐ）
+const maxUnknownRun = 4
+
+// commonSurnames はよくある姓（人名の結合に使う）
+const commonSurnames = "王李张刘陈杨黄赵吴周徐孙马朱胡郭何高林罗郑梁谢宋唐许韩冯邓曹彭曾肖田董袁潘于蒋蔡余杜叶程苏魏吕丁任沈姚卢姜崔钟谭陆汪范金石廖贾夏韦付方白邹孟熊秦邱江尹薛闫段雷侯龙史陶黎贺顾毛郝龚邵万钱严覃武戴莫孔向汤"
+
+// maxGivenNameFreq は名として結合する1文字の語の出現頻度の上限（これ以上の頻度の字は単独の語とみなす）
+const maxGivenNameFreq = 2000
+
+// dictionary は分かち書き用の辞書（単語ごとの出現頻度の対数確率）
+type dictionary struct {
+	logProb map[string]float64
+	// unknown は辞書にない1文字に使う対数確率
+	unknown float64
+	maxLen  int
+	// givenNameLogProb は名として結合する字の対数確率の上限
+	givenNameLogProb float64
+}
+
+var (
+	defaultDictOnce sync.Once
+	defaultDict     *dictionary
+)
+
+// getDefaultDict は組み込みの辞書を初回使用時に読み込んで返します
+func getDefaultDict() *dictionary {
+	defaultDictOnce.Do(func() {
+		defaultDict = loadDictionary(dictData)
+	})
+	return defaultDict
+}
+
+func loadDictionary(data string) *dictionary {
+	freq := map[string]float64{}
+	total := 0.0
+	maxLen := 1
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		f, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || f <= 0 {
+			continue
+		}
+		word := ToSimplified(fields[0])
+		freq[word] += f
+		total += f
+		maxLen = max(maxLen, len([]rune(word)))
+	}
+	d := &dictionary{logProb: make(map[string]float64, len(freq)), unknown: math.Log(1 / (total + 1)), maxLen: maxLen,
+		givenNameLogProb: math.Log(maxGivenNameFreq / total)}
+	for word, f := range freq {
+		d.logProb[word] = math.Log(f / total)
+	}
+	return d
+}
+
+// Token は分かち書きした語
+type Token struct {
+	// Surface は元のテキストでの表記（繁体字ならそのまま）
+	Surface string
+	// Key は簡体字に正規化した表記（集計に使う）
+	Key string
+}
+
+// ExtractChineseKeywords 中国語テキストからキーワードを抽出
+func ExtractChineseKeywords(text string) []string {
+	result, err := ExtractChineseKeywordsContext(context.Background(), text)
+	if err != nil {
+		return []string{}
+	}
+	return result
+}
+
+// ExtractChineseKeywordsContext は重複を除いたキーワードを簡体字に正規化して初出順で返します
+func ExtractChineseKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	tokens, err := TokenizeChineseKeywordsContext(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	seen := map[string]bool{}
+	for _, tok := range tokens {
+		if !seen[tok.Key] {
+			seen[tok.Key] = true
+			result = append(result, tok.Key)
+		}
+	}
+	return result, nil
+}
+
+// TokenizeChineseKeywordsContext 中国語テキストをキーワード候補の並びに分割（出現順）
+// 2文字以上の漢字の語のうちストップワードでないものを候補とします
+func TokenizeChineseKeywordsContext(ctx context.Context, text string) ([]Token, error) {
+	var result []Token
+	for i, tok := range Segment(text) {
+		if i%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if isKeywordCandidate(tok) {
+			result = append(result, tok)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Segment は中国語テキストを辞書による最大確率の分割で語に分けます（漢字以外の文字は区切りとして捨てます）
+// 辞書にない漢字が maxUnknownRun 文字まで続く場合は1語にまとめます（人名など）
+func Segment(text string) []Token {
+	var result []Token
+	var run []rune
+	dict := getDefaultDict()
+	flush := func() {
+		if len(run) > 0 {
+			result = append(result, dict.segment(run)...)
+			run = run[:0]
+		}
+	}
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			run = append(run, r)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return result
+}
+
+// segment は漢字の並びを動的計画法で分割します
+func (d *dictionary) segment(surface []rune) []Token {
+	key := []rune(ToSimplified(string(surface)))
+	n := len(key)
+	// best[i] は key[i:] を分割したときの最大の対数確率、next[i] はそのときの最初の語の終端
+	best := make([]float64, n+1)
+	next := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		best[i] = math.Inf(-1)
+		for j := i + 1; j <= n && j-i <= d.maxLen; j++ {
+			p, ok := d.logProb[string(key[i:j])]
+			if !ok {
+				if j-i > 1 {
+					continue
+				}
+				p = d.unknown
+			}
+			if score := p + best[j]; score > best[i] {
+				best[i] = score
+				next[i] = j
+			}
+		}
+	}
+	var result []Token
+	unknownStart := -1
+	flushUnknown := func(end int) {
+		if unknownStart < 0 {
+			return
+		}
+		if end-unknownStart <= maxUnknownRun {
+			result = append(result, Token{Surface: string(surface[unknownStart:end]), Key: string(key[unknownStart:end])})
+		} else {
+			for i := unknownStart; i < end; i++ {
+				result = append(result, Token{Surface: string(surface[i]), Key: string(key[i])})
+			}
+		}
+		unknownStart = -1
+	}
+	for i := 0; i < n; i = next[i] {
+		j := next[i]
+		if _, ok := d.logProb[string(key[i:j])]; !ok {
+			if unknownStart < 0 {
+				unknownStart = i
+			}
+			continue
+		}
+		flushUnknown(i)
+		result = append(result, Token{Surface: string(surface[i:j]), Key: string(key[i:j])})
+	}
+	flushUnknown(n)
+	return d.mergeNames(result)
+}
+
+// mergeNames は姓に続く1〜2文字の名を人名として1語にまとめます（"张" "伟" → "张伟"）
+// 名の字は単独の語として出現頻度が低いものに限ります（"一张纸" の "纸" などは結合しない）
+func (d *dictionary) mergeNames(tokens []Token) []Token {
+	var result []Token
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if !isSingleRune(tok.Key) || !strings.Contains(commonSurnames, tok.Key) {
+			result = append(result, tok)
+			continue
+		}
+		j := i + 1
+		for j < len(tokens) && j-i <= 2 && isSingleRune(tokens[j].Key) && d.isGivenNameChar(tokens[j].Key) {
+			j++
+		}
+		for _, t := range tokens[i+1 : j] {
+			tok.Surface += t.Surface
+			tok.Key += t.Key
+		}
+		result = append(result, tok)
+		i = j - 1
+	}
+	return result
+}
+
+// isGivenNameChar は名に使える字か（単独の語として出現頻度が低いか）判定します
+func (d *dictionary) isGivenNameChar(key string) bool {
+	p, ok := d.logProb[key]
+	return !ok || p < d.givenNameLogProb
+}
+
+func isSingleRune(s string) bool {
+	return utf8.RuneCountInString(s) == 1
+}
+
+// isKeywordCandidate はキーワード候補になる語か判定します
+func isKeywordCandidate(tok Token) bool {
+	if len([]rune(tok.Key)) < 2 {
+		return false
+	}
+	return !DefaultStopWords[tok.Key]
+}
@@ -0,0 +1,127 @@
+package chinese
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	var surfaces []string
+	for _, tok := range Segment("我们的公司提供云计算服务和人工智能解决方案。") {
+		surfaces = append(surfaces, tok.Surface)
+	}
+	expected := []string{"我们", "的", "公司", "提供", "云计算", "服务", "和", "人工智能", "解决方案"}
+	if !reflect.DeepEqual(surfaces, expected) {
+		t.Errorf("expected %v, got %v", expected, surfaces)
+	}
+}
+
+func TestSegment_UnknownWords(t *testing.T) {
+	// 姓に続く頻度の低い字は人名として1語にまとめ、よく使う字は分けたままにする
+	cases := []struct {
+		text     string
+		expected []string
+	}{
+		{"张伟的公司", []string{"张伟", "的", "公司"}},
+		{"王芳和李娜", []string{"王芳", "和", "李娜"}},
+		{"一张纸", []string{"一张", "纸"}},
+	}
+	for _, c := range cases {
+		var surfaces []string
+		for _, tok := range Segment(c.text) {
+			surfaces = append(surfaces, tok.Surface)
+		}
+		if !reflect.DeepEqual(surfaces, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.text, c.expected, surfaces)
+		}
+	}
+}
+
+func TestExtractChineseKeywords_Paragraph(t *testing.T) {
+	// 辞書にないフレーズ全体が1語にならないこと
+	keywords := ExtractChineseKeywords("中华人民共和国的首都是北京。这款手机的电池续航长达十二小时，支持快速充电。欢迎访问我们的网站。")
+	got := map[string]bool{}
+	for _, kw := range keywords {
+		got[kw] = true
+	}
+	for _, kw := range []string{"中华人民共和国", "首都", "北京", "手机", "电池", "续航", "充电", "网站"} {
+		if !got[kw] {
+			t.Errorf("expected keyword %s in %v", kw, keywords)
+		}
+	}
+	for _, kw := range []string{"长达十二小时", "支持快速充电", "欢迎访问", "人民"} {
+		if got[kw] {
+			t.Errorf("unexpected keyword %s in %v", kw, keywords)
+		}
+	}
+}
+
+func TestExtractChineseKeywords(t *testing.T) {
+	keywords := ExtractChineseKeywords("我们的公司提供云计算服务。云计算是未来的发展方向，我们可以帮助企业。")
+	expected := []string{"公司", "提供", "云计算", "服务", "未来", "发展", "方向", "帮助", "企业"}
+	if !reflect.DeepEqual(keywords, expected) {
+		t.Errorf("expected %v, got %v", expected, keywords)
+	}
+}
+
+func TestTraditionalNormalization(t *testing.T) {
+	// 繁体字は簡体字のキーにまとめ、表記は元のまま残す
+	tokens, err := TokenizeChineseKeywordsContext(context.Background(), "網絡安全與數據庫管理")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var keys, surfaces []string
+	for _, tok := range tokens {
+		keys = append(keys, tok.Key)
+		surfaces = append(surfaces, tok.Surface)
+	}
+	if !reflect.DeepEqual(keys, []string{"网络安全", "数据库", "管理"}) {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+	if !reflect.DeepEqual(surfaces, []string{"網絡安全", "數據庫", "管理"}) {
+		t.Errorf("unexpected surfaces: %v", surfaces)
+	}
+}
+
+func TestToSimplified(t *testing.T) {
+	if got := ToSimplified("連創備郵著鏡蘭傷寬註倉飲劍倫蓋讚緩輔"); got != "连创备邮着镜兰伤宽注仓饮剑伦盖赞缓辅" {
+		t.Errorf("unexpected simplified text: %s", got)
+	}
+	// 簡体字・日本語の字体・変換表にない文字はそのまま
+	if got := ToSimplified("连接设备 abc"); got != "连接设备 abc" {
+		t.Errorf("expected text to be kept, got %s", got)
+	}
+	if len(traditionalToSimplified) < 2500 {
+		t.Errorf("expected the full character table, got %d entries", len(traditionalToSimplified))
+	}
+}
+
+func TestTraditionalSegmentation(t *testing.T) {
+	// 繁体字でも簡体字と同じ語に分割する
+	for text, expected := range map[string][]string{
+		"我們提供雲計算服務和連接設備": {"提供", "云计算", "服务", "连接", "设备"},
+		"創新的郵件服務":        {"创新", "邮件", "服务"},
+	} {
+		tokens, err := TokenizeChineseKeywordsContext(context.Background(), text)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var keys []string
+		for _, tok := range tokens {
+			keys = append(keys, tok.Key)
+		}
+		if !reflect.DeepEqual(keys, expected) {
+			t.Errorf("%s: expected %v, got %v", text, expected, keys)
+		}
+	}
+}
+
+func TestTokenizeChineseKeywordsContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := TokenizeChineseKeywordsContext(ctx, "云计算服务"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package chinese
+
+import (
+	_ "embed"
+	"strings"
+	"unicode/utf8"
+)
+
+// ts_characters.txt は繁体字と簡体字の1文字ずつの対応（"繁体字\t簡体字" の行）
+// ICU の Hant-Hans 変換（Unihan の簡体字対応）から CJK 統合漢字・拡張A/B・互換漢字の全文字について生成したもの
+//
+//go:embed ts_characters.txt
+var tsCharactersData string
+
+// traditionalToSimplified は繁体字から簡体字への変換表
+// 1文字ずつの置き換えなので変換前後で文字位置は変わりません
+var traditionalToSimplified = buildTraditionalMap(tsCharactersData)
+
+func buildTraditionalMap(data string) map[rune]rune {
+	m := map[rune]rune{}
+	for _, line := range strings.Split(data, "\n") {
+		traditional, simplified, ok := strings.Cut(line, "\t")
+		if !ok || utf8.RuneCountInString(traditional) != 1 || utf8.RuneCountInString(simplified) != 1 {
+			continue
+		}
+		t, _ := utf8.DecodeRuneInString(traditional)
+		s, _ := utf8.DecodeRuneInString(simplified)
+		m[t] = s
+	}
+	return m
+}
+
+// ToSimplified は繁体字を簡体字に置き換えます（変換表にない文字はそのまま）
+func ToSimplified(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		if s, ok := traditionalToSimplified[r]; ok {
+			runes[i] = s
+		}
+	}
+	return string(runes)
+}
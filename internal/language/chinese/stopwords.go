@@ -0,0 +1,14 @@
+package chinese
+
+// DefaultStopWords は中国語のストップワード（簡体字）
+var DefaultStopWords = map[string]bool{
+	"我们": true, "你们": true, "他们": true, "她们": true, "自己": true,
+	"这个": true, "那个": true, "这些": true, "那些": true, "一个": true, "一些": true, "一样": true,
+	"所有": true, "每个": true, "其他": true, "以上": true, "以下": true, "之后": true, "之前": true,
+	"可以": true, "可能": true, "应该": true, "需要": true, "没有": true,
+	"什么": true, "怎么": true, "如何": true, "为什么": true,
+	"因为": true, "所以": true, "但是": true, "如果": true, "虽然": true, "然后": true,
+	"以及": true, "并且": true, "而且": true, "通过": true, "进行": true, "使用": true, "关于": true,
+	"时候": true, "方面": true, "情况": true, "这样": true, "那样": true, "非常": true,
+	"已经": true, "目前": true, "现在": true, "今天": true, "明天": true, "昨天": true, "今年": true,
+}
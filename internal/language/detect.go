@@ -0,0 +1,231 @@
+package language
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 言語コード（ISO 639-1）
+const (
+	English    = "en"
+	Japanese   = "ja"
+	Chinese    = "zh"
+	Korean     = "ko"
+	German     = "de"
+	French     = "fr"
+	Spanish    = "es"
+	Italian    = "it"
+	Portuguese = "pt"
+	// Undetermined は判定できなかった場合（文字が含まれないなど）
+	Undetermined = "und"
+)
+
+// Detection は言語判定の結果
+type Detection struct {
+	Lang string
+	// Confidence は 0〜1 の確からしさ
+	Confidence float64
+}
+
+// Hints は HTML・HTTPヘッダーから得られる言語の手がかり
+type Hints struct {
+	// HTMLLang は <html lang> 属性の値
+	HTMLLang string
+	// ContentLanguage は Content-Language ヘッダーの値
+	ContentLanguage string
+}
+
+// hintBonus は手がかりと一致する言語に加える類似度
+const hintBonus = 0.15
+
+// maxDetectRunes は判定に使う最大文字数
+const maxDetectRunes = 5000
+
+// cjkCharWeight はラテン文字と比べたときの漢字・かな・ハングル1文字の重み
+const cjkCharWeight = 3
+
+// latinProfiles はラテン文字言語ごとの trigram 出現頻度（ベクトルの大きさで正規化済み）
+var latinProfiles = buildProfiles()
+
+func buildProfiles() map[string]map[string]float64 {
+	profiles := map[string]map[string]float64{}
+	for lang, sample := range profileSamples {
+		profiles[lang] = normalizeVector(trigrams(sample))
+	}
+	return profiles
+}
+
+// Detect はテキストの言語を判定します
+func Detect(text string) Detection {
+	return DetectWithHints(text, Hints{})
+}
+
+// DetectWithHints はテキストの文字種と文字 trigram プロファイルで言語を判定します
+// 文字種（ハングル・かな・漢字・ラテン文字）で候補を絞り、ラテン文字は trigram の類似度で判定します
+// hints の言語が文字種と矛盾しない場合はその言語を優先します
+func DetectWithHints(text string, hints Hints) Detection {
+	hint := hintLanguage(hints)
+	var hangul, kana, han, latin int
+	runes := 0
+	for _, r := range text {
+		if runes >= maxDetectRunes {
+			break
+		}
+		runes++
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	cjk := hangul + kana + han
+	if cjk+latin == 0 {
+		if hint != "" {
+			return Detection{Lang: hint, Confidence: 0.5}
+		}
+		return Detection{Lang: Undetermined, Confidence: 0}
+	}
+	// 漢字・かな・ハングルは1文字あたりの情報量が多いため、ラテン文字より重く数える
+	weightedCJK := cjk * cjkCharWeight
+	letters := weightedCJK + latin
+
+	// CJK の手がかりがあり該当する文字を含む場合は、英語の製品名などが多くても CJK として扱う
+	if weightedCJK >= latin || (IsCJK(hint) && cjk > 0) {
+		share := math.Max(float64(weightedCJK)/float64(letters), 0.5)
+		switch {
+		case hangul > kana+han:
+			return Detection{Lang: Korean, Confidence: round2(share * float64(hangul) / float64(cjk))}
+		case kana > 0 && (float64(kana)/float64(kana+han) >= 0.05 || hint == Japanese):
+			return Detection{Lang: Japanese, Confidence: round2(share * math.Min(1, 0.7+float64(kana)/float64(kana+han)))}
+		case hint == Japanese:
+			// かなを含まない漢字のみのテキストは手がかりに従う
+			return Detection{Lang: Japanese, Confidence: round2(share * 0.8)}
+		default:
+			confidence := 0.6
+			if hint == Chinese {
+				confidence = 0.8
+			}
+			if kana == 0 {
+				confidence += 0.2
+			}
+			return Detection{Lang: Chinese, Confidence: round2(share * math.Min(1, confidence))}
+		}
+	}
+
+	// ラテン文字: trigram の余弦類似度
+	vector := normalizeVector(trigrams(truncateRunes(text, maxDetectRunes)))
+	scores := map[string]float64{}
+	for lang, profile := range latinProfiles {
+		scores[lang] = cosine(vector, profile)
+		if lang == hint {
+			scores[lang] += hintBonus
+		}
+	}
+	langs := make([]string, 0, len(scores))
+	total := 0.0
+	for lang, s := range scores {
+		langs = append(langs, lang)
+		total += s
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if scores[langs[i]] != scores[langs[j]] {
+			return scores[langs[i]] > scores[langs[j]]
+		}
+		return langs[i] < langs[j]
+	})
+	best := langs[0]
+	if total == 0 {
+		return Detection{Lang: English, Confidence: 0}
+	}
+	// 1位と2位の差が大きいほど確からしい
+	margin := (scores[best] - scores[langs[1]]) / scores[best]
+	confidence := math.Min(1, scores[best]/total*float64(len(langs))*0.25+margin)
+	share := float64(latin) / float64(letters)
+	return Detection{Lang: best, Confidence: round2(confidence * share)}
+}
+
+// hintLanguage は手がかりから対応している言語コードを返します（<html lang> を優先）
+func hintLanguage(hints Hints) string {
+	for _, v := range []string{hints.HTMLLang, hints.ContentLanguage} {
+		// "ja-JP", "en-US, en" などから主言語を取り出す
+		v = strings.ToLower(strings.TrimSpace(strings.Split(v, ",")[0]))
+		v = strings.Split(strings.ReplaceAll(v, "_", "-"), "-")[0]
+		if IsSupported(v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// IsSupported は判定・抽出に対応している言語コードか判定します
+func IsSupported(lang string) bool {
+	switch lang {
+	case English, Japanese, Chinese, Korean, German, French, Spanish, Italian, Portuguese:
+		return true
+	}
+	return false
+}
+
+// IsCJK は漢字・かな・ハングルを使う言語か判定します
+func IsCJK(lang string) bool {
+	return lang == Japanese || lang == Chinese || lang == Korean
+}
+
+// trigrams は小文字化した単語（前後に空白を補う）の文字 trigram の出現回数を返します
+func trigrams(text string) map[string]float64 {
+	result := map[string]float64{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])]++
+		}
+	}
+	return result
+}
+
+func normalizeVector(v map[string]float64) map[string]float64 {
+	norm := 0.0
+	for _, x := range v {
+		norm += x * x
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		return v
+	}
+	for k := range v {
+		v[k] /= norm
+	}
+	return v
+}
+
+func cosine(a, b map[string]float64) float64 {
+	sum := 0.0
+	for k, x := range a {
+		sum += x * b[k]
+	}
+	return sum
+}
+
+func truncateRunes(s string, n int) string {
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos]
+		}
+		i++
+	}
+	return s
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,65 @@
+package language
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"Kubernetes is an open source system for automating deployment, scaling and management of containerized applications.", English},
+		{"Kubernetes ist ein Open-Source-System zur Automatisierung der Bereitstellung und Verwaltung von Anwendungen in Containern.", German},
+		{"Kubernetes est un système open source qui permet d'automatiser le déploiement et la gestion des applications conteneurisées.", French},
+		{"Kubernetes es un sistema de código abierto para automatizar el despliegue y la gestión de aplicaciones en contenedores.", Spanish},
+		{"Kubernetes è un sistema open source per automatizzare la distribuzione e la gestione delle applicazioni containerizzate.", Italian},
+		{"O Kubernetes é um sistema de código aberto para automatizar a implantação e o gerenciamento de aplicações em contêineres.", Portuguese},
+		{"Kubernetes はコンテナ化されたアプリケーションのデプロイを自動化するためのシステムです。", Japanese},
+		{"Kubernetes 是一个开源系统，用于自动部署、扩展和管理容器化应用程序。", Chinese},
+		{"쿠버네티스는 컨테이너화된 애플리케이션의 배포를 자동화하는 오픈소스 시스템입니다.", Korean},
+	}
+	for _, c := range cases {
+		got := Detect(c.text)
+		if got.Lang != c.want {
+			t.Errorf("Detect(%q) = %s (%.2f), want %s", c.text, got.Lang, got.Confidence, c.want)
+		}
+		if got.Confidence <= 0 || got.Confidence > 1 {
+			t.Errorf("Detect(%q) confidence = %v, want (0, 1]", c.text, got.Confidence)
+		}
+	}
+}
+
+func TestDetectEmpty(t *testing.T) {
+	if got := Detect("123 !?"); got.Lang != Undetermined || got.Confidence != 0 {
+		t.Errorf("expected undetermined, got %+v", got)
+	}
+	if got := DetectWithHints("", Hints{HTMLLang: "de-DE"}); got.Lang != German {
+		t.Errorf("expected hint language for empty text, got %+v", got)
+	}
+}
+
+func TestDetectWithHints(t *testing.T) {
+	// 漢字のみのテキストは手がかりで日本語・中国語を判別する
+	if got := DetectWithHints("東京都新宿区", Hints{HTMLLang: "ja"}); got.Lang != Japanese {
+		t.Errorf("expected ja with html lang hint, got %+v", got)
+	}
+	if got := DetectWithHints("東京都新宿区", Hints{ContentLanguage: "zh-CN"}); got.Lang != Chinese {
+		t.Errorf("expected zh with Content-Language hint, got %+v", got)
+	}
+	// 文字種と矛盾する手がかりは無視する
+	if got := DetectWithHints("This page explains how to configure the server.", Hints{HTMLLang: "ja"}); got.Lang != English {
+		t.Errorf("expected en despite ja hint, got %+v", got)
+	}
+	// 一致する手がかりで確からしさが上がる
+	text := "Information about the product"
+	plain := Detect(text)
+	hinted := DetectWithHints(text, Hints{ContentLanguage: "en-US, en"})
+	if hinted.Lang != English || hinted.Confidence < plain.Confidence {
+		t.Errorf("expected hint to raise confidence: plain=%+v hinted=%+v", plain, hinted)
+	}
+}
+
+func TestDetectSingleKanjiInEnglish(t *testing.T) {
+	if got := Detect("Welcome to the 東 garden tour guide"); got.Lang != English {
+		t.Errorf("expected en for English text with a single kanji, got %+v", got)
+	}
+}
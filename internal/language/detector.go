@@ -37,3 +37,13 @@ func IsSymbolOrPunctuation(text string) bool {
 	}
 	return true
 }
+
+// ContainsHangul はテキストがハングルを含むか判定
+func ContainsHangul(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -42,3 +42,12 @@ func TestIsSymbolOrPunctuation(t *testing.T) {
 		t.Error("expected false for empty string")
 	}
 }
+
+func TestContainsHangul(t *testing.T) {
+	if !ContainsHangul("서울 Seoul") {
+		t.Error("expected true for Hangul text")
+	}
+	if ContainsHangul("東京 Tokyo") {
+		t.Error("expected false for non-Hangul text")
+	}
+}
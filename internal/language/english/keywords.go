@@ -6,17 +6,49 @@ import (
 	"strings"
 )
 
-// ExtractEnglishKeywords 英語テキストからキーワードを抽出（頻度順、正規化、代表単語選択）
-func ExtractEnglishKeywords(text string, stopWords map[string]int, normalizeKeyword func(string) string) []string {
-	clean := strings.ToLower(text)
-	clean = regexp.MustCompile(`[^\w\s-]`).ReplaceAllString(clean, " ")
+// TokenizeEnglishKeywords 英語テキストをキーワード候補の並び（出現順、ストップワード除外）に分割
+// TextRank など語順を使うスコアリング向け
+func TokenizeEnglishKeywords(text string, stopWords map[string]int, normalizeKeyword func(string) string) []string {
+	var result []string
+	for _, w := range splitEnglishWords(text) {
+		if IsKeywordCandidate(w, stopWords, normalizeKeyword) {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// IsKeywordCandidate 小文字化済みの単語がキーワード候補か判定（ストップワード・1文字・ハイフンを除外）
+func IsKeywordCandidate(w string, stopWords map[string]int, normalizeKeyword func(string) string) bool {
+	if _, skip := stopWords[w]; skip || len(w) <= 1 || w == "-" {
+		return false
+	}
+	norm := normalizeKeyword(w)
+	_, skip := stopWords[norm]
+	return !skip && len(norm) > 1 && norm != "-"
+}
+
+// splitEnglishWords 小文字化して記号を除去し単語に分割
+func splitEnglishWords(text string) []string {
+	return SplitEnglishWords(strings.ToLower(text))
+}
+
+// SplitEnglishWords 記号・漢字・かな・ハングルを除去して単語に分割（大文字・小文字は保持、"café" などアクセント付きの文字は残す）
+func SplitEnglishWords(text string) []string {
+	clean := regexp.MustCompile(`[^\p{L}\p{Mn}\p{N}_\s-]|[\p{Han}\p{Hiragana}\p{Katakana}\p{Hangul}]`).ReplaceAllString(text, " ")
 	clean = regexp.MustCompile(`-{2,}`).ReplaceAllString(clean, "-")
-	words := strings.Fields(clean)
+	return strings.Fields(clean)
+}
+
+// ExtractEnglishKeywords 英語テキストからキーワードを抽出（頻度順・同頻度は初出順、正規化、代表単語選択）
+func ExtractEnglishKeywords(text string, stopWords map[string]int, normalizeKeyword func(string) string) []string {
+	words := splitEnglishWords(text)
 
 	wordFreq := make(map[string]int)
 	normalizedWords := make(map[string][]string) // 正規化→元の単語のマッピング
+	firstIndex := make(map[string]int)           // 正規化→初出位置（同点時の並び順）
 
-	for _, w := range words {
+	for i, w := range words {
 		if _, skip := stopWords[w]; skip || len(w) <= 1 || w == "-" {
 			continue
 		}
@@ -26,6 +58,9 @@ func ExtractEnglishKeywords(text string, stopWords map[string]int, normalizeKeyw
 			if norm != w {
 				normalizedWords[norm] = append(normalizedWords[norm], w)
 			}
+			if _, ok := firstIndex[norm]; !ok {
+				firstIndex[norm] = i
+			}
 		}
 	}
 
@@ -38,6 +73,7 @@ func ExtractEnglishKeywords(text string, stopWords map[string]int, normalizeKeyw
 	type keywordWithScore struct {
 		Keyword string
 		Score   int
+		First   int
 	}
 	var resultList []keywordWithScore
 	for norm, score := range normalizedScores {
@@ -54,11 +90,16 @@ func ExtractEnglishKeywords(text string, stopWords map[string]int, normalizeKeyw
 		resultList = append(resultList, keywordWithScore{
 			Keyword: bestWord,
 			Score:   score,
+			First:   firstIndex[norm],
 		})
 	}
 
+	// 同頻度は初出順（実行ごとに順序が変わらないように）
 	sort.Slice(resultList, func(i, j int) bool {
-		return resultList[i].Score > resultList[j].Score
+		if resultList[i].Score != resultList[j].Score {
+			return resultList[i].Score > resultList[j].Score
+		}
+		return resultList[i].First < resultList[j].First
 	})
 
 	var result []string
@@ -1,6 +1,7 @@
 package english
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -25,3 +26,27 @@ func TestExtractEnglishKeywords(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractEnglishKeywords_Deterministic(t *testing.T) {
+	text := "zebra apple mango apple kiwi mango banana"
+	expected := []string{"apple", "mango", "zebra", "kiwi", "banana"}
+	for run := 0; run < 20; run++ {
+		keywords := ExtractEnglishKeywords(text, map[string]int{}, dummyNormalize)
+		if len(keywords) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, keywords)
+		}
+		for i := range expected {
+			if keywords[i] != expected[i] {
+				t.Fatalf("run %d: expected %v, got %v", run, expected, keywords)
+			}
+		}
+	}
+}
+
+func TestSplitEnglishWords_Accents(t *testing.T) {
+	words := SplitEnglishWords("Café crème, naïve résumé!")
+	expected := []string{"Café", "crème", "naïve", "résumé"}
+	if strings.Join(words, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %v, got %v", expected, words)
+	}
+}
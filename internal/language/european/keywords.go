@@ -0,0 +1,87 @@
+package european
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitWords はテキストを単語に分割します（文字・数字以外で区切り、大文字・小文字は保持）
+// アクセント付きの文字も単語の一部として扱い、"l'internet" のようなアポストロフィは区切りとします
+func SplitWords(text string) []string {
+	var result []string
+	for _, w := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r) && r != '-'
+	}) {
+		if w = strings.Trim(w, "-"); w != "" {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// IsKeywordCandidate 小文字化済みの単語がキーワード候補か判定（ストップワード・1文字・数字のみを除外）
+func IsKeywordCandidate(w string, stopWords map[string]int) bool {
+	if _, skip := stopWords[w]; skip || utf8.RuneCountInString(w) <= 1 {
+		return false
+	}
+	return strings.ContainsFunc(w, unicode.IsLetter)
+}
+
+// TokenizeKeywords テキストをキーワード候補の並び（出現順、小文字化、ストップワード除外）に分割
+func TokenizeKeywords(text string, stopWords map[string]int) []string {
+	var result []string
+	for _, w := range SplitWords(strings.ToLower(text)) {
+		if IsKeywordCandidate(w, stopWords) {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// Keyword は抽出したキーワードの表記と語幹
+type Keyword struct {
+	// Surface は語幹が同じ単語のうち最も多く現れた表記（同数なら先に現れた表記）
+	Surface string
+	// Stem は集計キーに使う語幹（Stem の結果）
+	Stem string
+}
+
+// ExtractKeywords テキストからキーワードを抽出（語幹ごとに集計し頻度順・同頻度は初出順）
+func ExtractKeywords(text, lang string, stopWords map[string]int) []Keyword {
+	type group struct {
+		stem  string
+		count int
+		first int
+		words map[string]int
+		best  string
+	}
+	groups := map[string]*group{}
+	var order []*group
+	for i, w := range TokenizeKeywords(text, stopWords) {
+		stem := Stem(w, lang)
+		g, ok := groups[stem]
+		if !ok {
+			g = &group{stem: stem, first: i, words: map[string]int{}}
+			groups[stem] = g
+			order = append(order, g)
+		}
+		g.count++
+		g.words[w]++
+		if g.best == "" || g.words[w] > g.words[g.best] {
+			g.best = w
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].count != order[j].count {
+			return order[i].count > order[j].count
+		}
+		return order[i].first < order[j].first
+	})
+	result := make([]Keyword, 0, len(order))
+	for _, g := range order {
+		result = append(result, Keyword{Surface: g.best, Stem: g.stem})
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package european
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	words := SplitWords("L'internet a changé la façon -- dont les gens travaillent: e-mail, 2024!")
+	expected := []string{"L", "internet", "a", "changé", "la", "façon", "dont", "les", "gens", "travaillent", "e-mail", "2024"}
+	if !reflect.DeepEqual(words, expected) {
+		t.Errorf("expected %v, got %v", expected, words)
+	}
+}
+
+func TestStem(t *testing.T) {
+	cases := []struct {
+		word, lang, want string
+	}{
+		{"Häuser", German, "haus"},
+		{"Hauses", German, "haus"},
+		{"journaux", French, "journal"},
+		{"entreprises", French, "entrepris"},
+		{"entreprise", French, "entrepris"},
+		{"françaises", French, "français"},
+		{"française", French, "français"},
+		{"ciudades", Spanish, "ciudad"},
+		{"empresas", Spanish, "empres"},
+		{"empresa", Spanish, "empres"},
+		{"amiche", Italian, "amic"},
+		{"aziende", Italian, "azi"},
+		{"azienda", Italian, "azi"},
+		{"informações", Portuguese, "inform"},
+		{"informação", Portuguese, "inform"},
+	}
+	for _, c := range cases {
+		if got := Stem(c.word, c.lang); got != c.want {
+			t.Errorf("Stem(%q, %s) = %q, want %q", c.word, c.lang, got, c.want)
+		}
+	}
+}
+
+func TestExtractKeywords(t *testing.T) {
+	stopWords := map[string]int{"die": 0, "der": 0, "das": 0, "und": 0, "für": 0, "am": 0}
+	text := "Die Häuser der Stadt. Das Haus für Familien und die Häuser am See."
+	keywords := ExtractKeywords(text, German, stopWords)
+	// 語幹が同じ "häuser" と "haus" はまとめ、多く現れた表記を返す
+	expected := []Keyword{{"häuser", "haus"}, {"stadt", "stadt"}, {"familien", "famili"}, {"see", "see"}}
+	if !reflect.DeepEqual(keywords, expected) {
+		t.Errorf("expected %v, got %v", expected, keywords)
+	}
+}
@@ -0,0 +1,48 @@
+package european
+
+import (
+	"strings"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/blevesearch/snowballstem/french"
+	"github.com/blevesearch/snowballstem/german"
+	"github.com/blevesearch/snowballstem/italian"
+	"github.com/blevesearch/snowballstem/portuguese"
+	"github.com/blevesearch/snowballstem/spanish"
+)
+
+// 言語コード
+const (
+	German     = "de"
+	French     = "fr"
+	Spanish    = "es"
+	Italian    = "it"
+	Portuguese = "pt"
+)
+
+// stemmers は言語ごとの Snowball ステマー
+var stemmers = map[string]func(*snowballstem.Env) bool{
+	German:     german.Stem,
+	French:     french.Stem,
+	Spanish:    spanish.Stem,
+	Italian:    italian.Stem,
+	Portuguese: portuguese.Stem,
+}
+
+// IsSupported は対応している言語か判定します
+func IsSupported(lang string) bool {
+	_, ok := stemmers[lang]
+	return ok
+}
+
+// Stem は小文字化した単語の語幹を Snowball ステマーで求めます（集計キーに使い、表示には使いません）
+func Stem(word, lang string) string {
+	w := strings.ToLower(word)
+	stem, ok := stemmers[lang]
+	if !ok {
+		return w
+	}
+	env := snowballstem.NewEnv(w)
+	stem(env)
+	return env.Current()
+}
@@ -0,0 +1,121 @@
+package japanese
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// 辞書名
+const (
+	// DictIPA は組み込みの IPA 辞書
+	DictIPA = "ipa"
+	// DictUni は組み込みの UniDic（初回使用時に読み込み）
+	DictUni = "uni"
+)
+
+// Options は形態素解析に使う辞書の設定
+type Options struct {
+	// Dictionary は辞書の種類（DictIPA, DictUni）。空なら DictIPA
+	Dictionary string
+	// DictionaryFile は kagome 形式の辞書ファイルのパス。空なら Dictionary の組み込み辞書を使う
+	DictionaryFile string
+	// UserDictFile は kagome のユーザー辞書（CSV: 表層形,分割,読み,品詞）のパス
+	UserDictFile string
+}
+
+// Tokenizer は辞書を読み込んだ日本語のキーワード抽出器
+type Tokenizer struct {
+	t          *tokenizer.Tokenizer
+	dictionary string
+	filter     Filter
+}
+
+// fileStamp は辞書ファイルの更新日時とサイズ（変更の検出に使う）
+type fileStamp struct {
+	modTime int64
+	size    int64
+}
+
+// cachedTokenizer は読み込んだときの辞書ファイルの状態と Tokenizer
+type cachedTokenizer struct {
+	dictStamp fileStamp
+	userStamp fileStamp
+	tokenizer *Tokenizer
+}
+
+var (
+	tokenizerMu    sync.Mutex
+	tokenizerCache = map[Options]cachedTokenizer{}
+)
+
+// NewTokenizer は辞書・ユーザー辞書を読み込んだ Tokenizer を返します
+// 同じ設定の Tokenizer は使い回し、辞書ファイル・ユーザー辞書が更新されていれば読み込み直します
+func NewTokenizer(opts Options) (*Tokenizer, error) {
+	if opts.Dictionary == "" {
+		opts.Dictionary = DictIPA
+	}
+	dictStamp := stampOf(opts.DictionaryFile)
+	userStamp := stampOf(opts.UserDictFile)
+	tokenizerMu.Lock()
+	defer tokenizerMu.Unlock()
+	if c, ok := tokenizerCache[opts]; ok && c.dictStamp == dictStamp && c.userStamp == userStamp {
+		return c.tokenizer, nil
+	}
+	d, err := loadDict(opts)
+	if err != nil {
+		return nil, err
+	}
+	tokenizerOpts := []tokenizer.Option{tokenizer.OmitBosEos()}
+	if opts.UserDictFile != "" {
+		udict, err := dict.NewUserDict(opts.UserDictFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load user dictionary '%s': %w", opts.UserDictFile, err)
+		}
+		tokenizerOpts = append(tokenizerOpts, tokenizer.UserDict(udict))
+	}
+	t, err := tokenizer.New(d, tokenizerOpts...)
+	if err != nil {
+		return nil, err
+	}
+	result := &Tokenizer{t: t, dictionary: opts.Dictionary, filter: DefaultFilter(opts.Dictionary)}
+	tokenizerCache[opts] = cachedTokenizer{dictStamp: dictStamp, userStamp: userStamp, tokenizer: result}
+	return result, nil
+}
+
+// stampOf はファイルの更新日時とサイズを返します（パスが空・取得できない場合はゼロ値）
+func stampOf(path string) fileStamp {
+	if path == "" {
+		return fileStamp{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime().UnixNano(), size: info.Size()}
+}
+
+// loadDict は設定に応じた辞書を返します
+func loadDict(opts Options) (*dict.Dict, error) {
+	switch opts.Dictionary {
+	case DictIPA, DictUni:
+	default:
+		return nil, fmt.Errorf("unknown Japanese dictionary '%s'", opts.Dictionary)
+	}
+	if opts.DictionaryFile == "" {
+		if opts.Dictionary == DictUni {
+			return uni.Dict(), nil
+		}
+		return ipa.Dict(), nil
+	}
+	d, err := dict.LoadDictFile(opts.DictionaryFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load Japanese dictionary '%s': %w", opts.DictionaryFile, err)
+	}
+	return d, nil
+}
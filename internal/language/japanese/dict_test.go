@@ -0,0 +1,101 @@
+package japanese
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewTokenizer_UserDict(t *testing.T) {
+	text := "東京スカイツリーの展望台"
+	keywords := ExtractJapaneseKeywords(text)
+	if slices.Contains(keywords, "東京スカイツリー") {
+		t.Fatalf("expected the IPA dictionary to split the term, got %v", keywords)
+	}
+
+	path := filepath.Join(t.TempDir(), "userdict.txt")
+	userDict := "# ブランド名\n東京スカイツリー,東京 スカイツリー,トウキョウ スカイツリー,カスタム名詞\n"
+	if err := os.WriteFile(path, []byte(userDict), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tok, err := NewTokenizer(Options{UserDictFile: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keywords, err = tok.ExtractKeywordsContext(context.Background(), text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(keywords, "東京スカイツリー") || slices.Contains(keywords, "スカイ") {
+		t.Errorf("expected the user dictionary term as one keyword, got %v", keywords)
+	}
+	if again, _ := NewTokenizer(Options{Dictionary: DictIPA, UserDictFile: path}); again != tok {
+		t.Error("expected the tokenizer to be reused for the same options")
+	}
+}
+
+func TestNewTokenizer_Errors(t *testing.T) {
+	cases := []Options{
+		{Dictionary: "unknown"},
+		{DictionaryFile: filepath.Join(t.TempDir(), "missing.dict")},
+		{UserDictFile: filepath.Join(t.TempDir(), "missing.txt")},
+	}
+	for _, opts := range cases {
+		if _, err := NewTokenizer(opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestNewTokenizer_Uni(t *testing.T) {
+	// UniDic は辞書ファイルを指定しなくても組み込みの辞書を使う
+	tok, err := NewTokenizer(Options{Dictionary: DictUni})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keywords, err := tok.ExtractKeywordsContext(context.Background(), "東京の展望台")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// UniDic は短単位で分割する（"展望台" → "展望" "台"）
+	if !slices.Contains(keywords, "東京") || !slices.Contains(keywords, "展望") {
+		t.Errorf("unexpected keywords: %v", keywords)
+	}
+}
+
+func TestNewTokenizer_UserDictReload(t *testing.T) {
+	// ユーザー辞書が更新されたら読み込み直す
+	path := filepath.Join(t.TempDir(), "userdict.txt")
+	if err := os.WriteFile(path, []byte("東京スカイツリー,東京 スカイツリー,トウキョウ スカイツリー,カスタム名詞\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tok, err := NewTokenizer(Options{UserDictFile: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	userDict := "東京スカイツリー,東京スカイツリー,トウキョウスカイツリー,カスタム名詞\n展望台見学,展望台見学,テンボウダイケンガク,カスタム名詞\n"
+	if err := os.WriteFile(path, []byte(userDict), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := NewTokenizer(Options{UserDictFile: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reloaded == tok {
+		t.Fatal("expected the tokenizer to be reloaded after the user dictionary changed")
+	}
+	keywords, err := reloaded.ExtractKeywordsContext(context.Background(), "東京スカイツリーの展望台見学")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(keywords, "展望台見学") {
+		t.Errorf("expected the new user dictionary term, got %v", keywords)
+	}
+}
@@ -0,0 +1,161 @@
+package japanese
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Filter はキーワード候補とするトークンの条件
+type Filter struct {
+	// Allow はキーワード候補とする品詞（品詞・品詞細分類1… を先頭から比較、"*" は任意）
+	// いずれかに一致すれば候補とする。空なら辞書ごとの既定（DefaultAllow）
+	Allow [][]string
+	// Deny は除外する品詞（Allow より優先）
+	Deny [][]string
+	// BaseForm が true なら活用する語（動詞・形容詞など）を基本形で返す
+	BaseForm bool
+	// MinLength は候補とする最小文字数
+	MinLength int
+	// KanjiMinLength は漢字のみの語の最小文字数（MinLength の代わりに使う）
+	KanjiMinLength int
+	// StopWords はキーワードから除く語（表層形または基本形で比較）
+	StopWords map[string]int
+}
+
+// DefaultAllow は辞書ごとの既定の品詞条件（一般名詞・固有名詞など）を返します
+// IPA 辞書と UniDic では品詞の体系が異なります
+func DefaultAllow(dictionary string) [][]string {
+	if dictionary == DictUni {
+		return [][]string{{"名詞", "普通名詞"}, {"名詞", "固有名詞"}}
+	}
+	return [][]string{{"名詞", "一般"}, {"名詞", "固有名詞"}, {"名詞", "サ変接続"}, {"名詞", "形容動詞語幹"}}
+}
+
+// DefaultFilter は既定の条件（辞書ごとの名詞、1文字の語は漢字のみ残す）を返します
+func DefaultFilter(dictionary string) Filter {
+	return Filter{Allow: DefaultAllow(dictionary), MinLength: 2, KanjiMinLength: 1}
+}
+
+// ParsePOSRules は "名詞,固有名詞" のようなカンマ区切りの品詞条件を分割します
+func ParsePOSRules(rules []string) [][]string {
+	result := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		var parts []string
+		for _, p := range strings.Split(rule, ",") {
+			parts = append(parts, strings.TrimSpace(p))
+		}
+		result = append(result, parts)
+	}
+	return result
+}
+
+// WithFilter は条件を差し替えた Tokenizer を返します（辞書は共有）
+// f.Allow が空なら辞書ごとの既定の品詞条件を使います
+func (t *Tokenizer) WithFilter(f Filter) *Tokenizer {
+	if len(f.Allow) == 0 {
+		f.Allow = DefaultAllow(t.dictionary)
+	}
+	copied := *t
+	copied.filter = f
+	return &copied
+}
+
+// keywordSurface はトークンがキーワード候補なら返す文字列（表層形または基本形）を返します
+func (t *Tokenizer) keywordSurface(token tokenizer.Token) (string, bool) {
+	surface := token.Surface
+	// ユーザー辞書の語はブランド名・専門用語として品詞を問わず残す
+	if token.Class != tokenizer.USER {
+		features := token.Features()
+		if !matchPOS(t.filter.Allow, features) || matchPOS(t.filter.Deny, features) {
+			return "", false
+		}
+		if t.filter.BaseForm {
+			if base, ok := token.BaseForm(); ok && base != "" && base != "*" {
+				surface = base
+			}
+		}
+	}
+	if !t.longEnough(surface) || isSymbolOrPunctuation(surface) {
+		return "", false
+	}
+	if _, ok := t.filter.StopWords[surface]; ok && token.Class != tokenizer.USER {
+		return "", false
+	}
+	return surface, true
+}
+
+// Reading は語の読み（カタカナ）を返します。読みのない部分（英数字や未知語）は小文字の表記を使います
+// 「問合せ」と「問い合わせ」のように送り仮名が異なる表記を同じ語として扱うために使います
+func (t *Tokenizer) Reading(word string) string {
+	var b strings.Builder
+	for _, token := range t.t.Tokenize(word) {
+		reading, ok := token.Reading()
+		if token.Class == tokenizer.USER {
+			if extra := token.UserExtra(); extra != nil {
+				reading, ok = strings.Join(extra.Readings, ""), true
+			}
+		}
+		if !ok || reading == "" || reading == "*" {
+			reading = strings.ToLower(token.Surface)
+		}
+		b.WriteString(reading)
+	}
+	return b.String()
+}
+
+// MergeKey は読みで集計するときのキー（読みと、かなを除いた表記の組）を返します
+// 読みとかな以外の文字の並びがどちらも同じ語だけをまとめます
+// 「問合せ」と「問い合わせ」は同じキー、同音異義語の「科学」と「化学」は別のキーになります
+func (t *Tokenizer) MergeKey(word string) string {
+	return t.Reading(word) + "/" + withoutKana(word)
+}
+
+// withoutKana はひらがな・カタカナ・長音符を除いた文字列を返します
+func withoutKana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == 'ー' || unicode.In(r, unicode.Hiragana, unicode.Katakana) {
+			return -1
+		}
+		return r
+	}, strings.ToLower(text))
+}
+
+// longEnough は最小文字数を満たすか判定します（漢字のみの語は KanjiMinLength）
+func (t *Tokenizer) longEnough(surface string) bool {
+	minLength := t.filter.MinLength
+	if isKanjiOnly(surface) {
+		minLength = t.filter.KanjiMinLength
+	}
+	return len([]rune(surface)) >= minLength
+}
+
+// matchPOS は品詞がいずれかの条件に一致するか判定します
+func matchPOS(rules [][]string, features []string) bool {
+	for _, rule := range rules {
+		if len(rule) == 0 || len(rule) > len(features) {
+			continue
+		}
+		matched := true
+		for i, p := range rule {
+			if p != "*" && p != "" && p != features[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isKanjiOnly(text string) bool {
+	for _, r := range text {
+		if !unicode.In(r, unicode.Han) {
+			return false
+		}
+	}
+	return text != ""
+}
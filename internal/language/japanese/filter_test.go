@@ -0,0 +1,114 @@
+package japanese
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestMatchPOS(t *testing.T) {
+	uni := DefaultAllow(DictUni)
+	if !matchPOS(uni, []string{"名詞", "普通名詞", "一般"}) || !matchPOS(uni, []string{"名詞", "固有名詞", "人名"}) {
+		t.Error("expected common and proper nouns to be keywords with UniDic")
+	}
+	if matchPOS(uni, []string{"名詞", "数詞"}) || matchPOS(uni, []string{"動詞", "一般"}) {
+		t.Error("expected numerals and verbs to be excluded with UniDic")
+	}
+	rules := ParsePOSRules([]string{"動詞, *, *", "名詞"})
+	if !matchPOS(rules, []string{"動詞", "自立", "*", "*"}) || !matchPOS(rules, []string{"名詞", "数"}) {
+		t.Error("expected wildcard and prefix rules to match")
+	}
+	if matchPOS(rules, []string{"動詞"}) {
+		t.Error("expected rules longer than the features not to match")
+	}
+}
+
+func TestWithFilter(t *testing.T) {
+	tok, err := NewTokenizer(Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := "田中さんが東京で新しいカメラを買った"
+	ctx := context.Background()
+
+	keywords, _ := tok.ExtractKeywordsContext(ctx, text)
+	if !slices.Contains(keywords, "東京") || !slices.Contains(keywords, "カメラ") || slices.Contains(keywords, "買う") {
+		t.Errorf("unexpected default keywords: %v", keywords)
+	}
+
+	// 固有名詞のみ
+	proper := tok.WithFilter(Filter{Allow: ParsePOSRules([]string{"名詞,固有名詞"}), MinLength: 1})
+	keywords, _ = proper.ExtractKeywordsContext(ctx, text)
+	if !slices.Equal(keywords, []string{"田中", "東京"}) {
+		t.Errorf("expected only proper nouns, got %v", keywords)
+	}
+
+	// 動詞・形容詞を基本形で含める
+	intent := tok.WithFilter(Filter{
+		Allow:    append(DefaultAllow(DictIPA), ParsePOSRules([]string{"動詞,自立", "形容詞,自立"})...),
+		Deny:     ParsePOSRules([]string{"名詞,固有名詞,人名"}),
+		BaseForm: true,
+	})
+	keywords, _ = intent.ExtractKeywordsContext(ctx, text)
+	if !slices.Contains(keywords, "買う") || !slices.Contains(keywords, "新しい") || slices.Contains(keywords, "田中") {
+		t.Errorf("expected base forms of verbs and adjectives without person names, got %v", keywords)
+	}
+
+	// 元の Tokenizer の条件は変わらない
+	if keywords, _ := tok.ExtractKeywordsContext(ctx, text); slices.Contains(keywords, "買う") {
+		t.Errorf("expected WithFilter not to modify the original tokenizer, got %v", keywords)
+	}
+}
+
+func TestLongEnough(t *testing.T) {
+	tok := &Tokenizer{filter: Filter{MinLength: 3, KanjiMinLength: 2}}
+	if tok.longEnough("カメ") || !tok.longEnough("カメラ") || tok.longEnough("本") || !tok.longEnough("東京") {
+		t.Error("unexpected minimum length result")
+	}
+}
+
+func TestStopWords(t *testing.T) {
+	tok, err := NewTokenizer(Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f := DefaultFilter(DictIPA)
+	f.StopWords = map[string]int{"方法": 0, "情報": 0}
+	keywords, _ := tok.WithFilter(f).ExtractKeywordsContext(context.Background(), "設定方法とサーバーの情報")
+	if !slices.Equal(keywords, []string{"設定", "サーバー"}) {
+		t.Errorf("expected stop words to be removed, got %v", keywords)
+	}
+}
+
+func TestReading(t *testing.T) {
+	tok, err := NewTokenizer(Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a, b := tok.Reading("問合せ"), tok.Reading("問い合わせ"); a != b || a != "トイアワセ" {
+		t.Errorf("expected the same reading, got %q and %q", a, b)
+	}
+	if got := tok.Reading("Go言語"); got != "goゲンゴ" {
+		t.Errorf("expected lowercase surface for words without reading, got %q", got)
+	}
+}
+
+func TestMergeKey(t *testing.T) {
+	tok, err := NewTokenizer(Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a, b := tok.MergeKey("問合せ"), tok.MergeKey("問い合わせ"); a != b {
+		t.Errorf("expected okurigana variants to share a key, got %q and %q", a, b)
+	}
+	// 同音異義語はまとめない
+	for _, words := range [][]string{{"科学", "化学"}, {"公園", "講演", "公演"}} {
+		keys := map[string]bool{}
+		for _, w := range words {
+			keys[tok.MergeKey(w)] = true
+		}
+		if len(keys) != len(words) {
+			t.Errorf("expected homophones %v to have different keys, got %v", words, keys)
+		}
+	}
+}
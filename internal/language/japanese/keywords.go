@@ -1,54 +1,87 @@
 package japanese
 
 import (
+	"context"
 	"strings"
 	"unicode"
-
-	"github.com/ikawaha/kagome-dict/ipa"
-	"github.com/ikawaha/kagome/v2/tokenizer"
 )
 
 // ExtractJapaneseKeywords 日本語テキストからキーワードを抽出
 func ExtractJapaneseKeywords(text string) []string {
-	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+	result, err := ExtractJapaneseKeywordsContext(context.Background(), text)
 	if err != nil {
 		return []string{}
 	}
-	tokens := t.Tokenize(text)
-	keywordMap := make(map[string]bool)
+	return result
+}
+
+// ExtractJapaneseKeywordsContext は重複を除いたキーワードを初出順で返します（組み込みの IPA 辞書を使用）
+// ctx がキャンセルされるとトークン処理を中断して ctx.Err() を返します
+func ExtractJapaneseKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	t, err := NewTokenizer(Options{})
+	if err != nil {
+		return nil, err
+	}
+	return t.ExtractKeywordsContext(ctx, text)
+}
+
+// TokenizeJapaneseKeywordsContext 日本語テキストをキーワード候補（名詞）の並びに分割（出現順、組み込みの IPA 辞書を使用）
+// TextRank など語順を使うスコアリング向け
+func TokenizeJapaneseKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	t, err := NewTokenizer(Options{})
+	if err != nil {
+		return nil, err
+	}
+	return t.TokenizeKeywordsContext(ctx, text)
+}
+
+// ExtractKeywordsContext は重複を除いたキーワードを初出順で返します
+func (t *Tokenizer) ExtractKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	surfaces, err := t.TokenizeKeywordsContext(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	// 初出順を保ったまま重複を除く（実行ごとに順序が変わらないように）
+	var order []string
 	normalizedMap := make(map[string]string)
-	for _, token := range tokens {
-		features := token.Features()
-		if len(features) == 0 || features[0] != "名詞" {
-			continue
-		}
-		if len(features) <= 1 || !(features[1] == "一般" || features[1] == "固有名詞" || features[1] == "サ変接続" || features[1] == "形容動詞語幹") {
-			continue
-		}
-		surface := token.Surface
-		runes := []rune(surface)
-		charLength := len(runes)
-		if charLength == 1 && !unicode.In(runes[0], unicode.Han) {
-			continue
-		}
-		if isSymbolOrPunctuation(surface) {
-			continue
-		}
+	for _, surface := range surfaces {
 		normalized := strings.ToLower(surface)
-		keywordMap[normalized] = true
-		if existing, ok := normalizedMap[normalized]; !ok || len(surface) > len(existing) {
+		existing, ok := normalizedMap[normalized]
+		if !ok {
+			order = append(order, normalized)
+		}
+		if !ok || len(surface) > len(existing) {
 			normalizedMap[normalized] = surface
 		}
 	}
-	result := make([]string, 0, len(keywordMap))
-	for norm := range keywordMap {
-		if original, ok := normalizedMap[norm]; ok {
-			result = append(result, original)
-		} else {
-			result = append(result, norm)
+	result := make([]string, 0, len(order))
+	for _, norm := range order {
+		result = append(result, normalizedMap[norm])
+	}
+	return result, nil
+}
+
+// TokenizeKeywordsContext はテキストを Filter の条件に合うキーワード候補（既定では名詞）とユーザー辞書の語の並びに分割します（出現順）
+func (t *Tokenizer) TokenizeKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	tokens := t.t.Tokenize(text)
+	var result []string
+	for i, token := range tokens {
+		// 長文でも応答できるよう一定間隔でキャンセルを確認
+		if i%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 		}
+		surface, ok := t.keywordSurface(token)
+		if !ok {
+			continue
+		}
+		result = append(result, surface)
 	}
-	return result
+	return result, nil
 }
 
 // isSymbolOrPunctuation 日本語用: 記号や特殊文字のみか判定
@@ -1,6 +1,9 @@
 package japanese
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -35,3 +38,26 @@ func TestIsSymbolOrPunctuation(t *testing.T) {
 		t.Error("expected false for empty string")
 	}
 }
+
+func TestExtractJapaneseKeywordsContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ExtractJapaneseKeywordsContext(ctx, "これはテスト用の文章です。")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestExtractJapaneseKeywords_FirstOccurrenceOrder(t *testing.T) {
+	text := "東京と大阪と京都。大阪の天気。"
+	first := ExtractJapaneseKeywords(text)
+	if len(first) < 3 || first[0] != "東京" || first[1] != "大阪" || first[2] != "京都" {
+		t.Fatalf("expected [東京 大阪 京都 ...], got %v", first)
+	}
+	for run := 0; run < 10; run++ {
+		keywords := ExtractJapaneseKeywords(text)
+		if strings.Join(keywords, ",") != strings.Join(first, ",") {
+			t.Fatalf("run %d: expected %v, got %v", run, first, keywords)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package korean
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	ko "github.com/ikawaha/kagome-dict-ko"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// 品詞（mecab-ko-dic の品詞タグ）
+const (
+	// posCommonNoun は一般名詞
+	posCommonNoun = "NNG"
+	// posProperNoun は固有名詞
+	posProperNoun = "NNP"
+	// posNounSuffix は名詞派生接尾辞（"자동화" の "화" など）
+	posNounSuffix = "XSN"
+	// pluralSuffix は複数を表す接尾辞（キーワードには含めない）
+	pluralSuffix = "들"
+)
+
+// stopWords は韓国語のストップワード（名詞として解析される語）
+var stopWords = map[string]bool{
+	"우리": true, "저희": true, "여러분": true, "이것": true, "그것": true, "저것": true, "여기": true, "거기": true,
+	"때문": true, "경우": true, "대한": true, "통해": true, "위해": true, "관련": true, "정도": true,
+	"지금": true, "오늘": true, "이번": true, "다음": true, "자신": true, "무엇": true,
+}
+
+var (
+	tokenizerOnce sync.Once
+	koTokenizer   *tokenizer.Tokenizer
+	tokenizerErr  error
+)
+
+// getTokenizer は ko-dic（mecab-ko-dic）を初回使用時に読み込んだ形態素解析器を返します
+func getTokenizer() (*tokenizer.Tokenizer, error) {
+	tokenizerOnce.Do(func() {
+		koTokenizer, tokenizerErr = tokenizer.New(ko.Dict(), tokenizer.OmitBosEos())
+		if tokenizerErr != nil {
+			tokenizerErr = fmt.Errorf("Failed to load the Korean dictionary: %w", tokenizerErr)
+		}
+	})
+	return koTokenizer, tokenizerErr
+}
+
+// ExtractKoreanKeywords 韓国語テキストからキーワードを抽出
+func ExtractKoreanKeywords(text string) []string {
+	result, err := ExtractKoreanKeywordsContext(context.Background(), text)
+	if err != nil {
+		return []string{}
+	}
+	return result
+}
+
+// ExtractKoreanKeywordsContext は重複を除いたキーワードを初出順で返します
+func ExtractKoreanKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	nouns, err := TokenizeKoreanKeywordsContext(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	seen := map[string]bool{}
+	for _, noun := range nouns {
+		if !seen[noun] {
+			seen[noun] = true
+			result = append(result, noun)
+		}
+	}
+	return result, nil
+}
+
+// TokenizeKoreanKeywordsContext 韓国語テキストを ko-dic で形態素解析し、名詞の候補を出現順に返します
+// 続けて現れる名詞と名詞派生接尾辞は1語にまとめ（"자동화"）、助詞・語尾・複数の "들" は除きます
+// 2音節以上のハングルの語のうちストップワードでないものを候補とします
+func TokenizeKoreanKeywordsContext(ctx context.Context, text string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	t, err := getTokenizer()
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	var noun strings.Builder
+	flush := func() {
+		word := noun.String()
+		noun.Reset()
+		if utf8.RuneCountInString(word) < 2 || !isHangul(word) || stopWords[word] {
+			return
+		}
+		result = append(result, word)
+	}
+	for i, tok := range t.Tokenize(text) {
+		if i%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		switch pos := tok.POS(); {
+		case len(pos) > 0 && (pos[0] == posCommonNoun || pos[0] == posProperNoun):
+			noun.WriteString(tok.Surface)
+		case len(pos) > 0 && pos[0] == posNounSuffix && tok.Surface != pluralSuffix && noun.Len() > 0:
+			noun.WriteString(tok.Surface)
+		default:
+			flush()
+		}
+	}
+	flush()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isHangul は語がハングルのみで構成されているか判定します
+func isHangul(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Hangul, r) {
+			return false
+		}
+	}
+	return word != ""
+}
@@ -0,0 +1,56 @@
+package korean
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTokenizeKoreanKeywordsContext(t *testing.T) {
+	cases := map[string][]string{
+		"서울에서":   {"서울"},
+		"서울의":    {"서울"},
+		"개발자들은":  {"개발자"},
+		"데이터를":   {"데이터"},
+		"시스템입니다": {"시스템"},
+		"관리합니다":  {"관리"},
+		"자동화하는":  {"자동화"},
+		"속도":     {"속도"},
+		"회의":     {"회의"},
+		// 末尾が助詞と同じ音節の名詞は切らない
+		"전문가":   {"전문가"},
+		"민주주의":  {"민주주의"},
+		"고양이":   {"고양이"},
+		"바나나":   {"바나나"},
+		"어린이":   {"어린이"},
+		"전문가가":  {"전문가"},
+		"어린이들은": {"어린이"},
+	}
+	for word, want := range cases {
+		got, err := TokenizeKoreanKeywordsContext(context.Background(), word)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("TokenizeKoreanKeywordsContext(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestExtractKoreanKeywords(t *testing.T) {
+	text := "서울에서 클라우드 행사입니다. 서울의 개발자들은 클라우드를 사용합니다. 그리고 우리는 서울을 방문합니다."
+	keywords := ExtractKoreanKeywords(text)
+	expected := []string{"서울", "클라우드", "행사", "개발자", "사용", "방문"}
+	if !reflect.DeepEqual(keywords, expected) {
+		t.Errorf("expected %v, got %v", expected, keywords)
+	}
+}
+
+func TestTokenizeKoreanKeywordsContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := TokenizeKoreanKeywordsContext(ctx, "서울에서"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions はキーワード抽出前の正規化の設定
+type NormalizeOptions struct {
+	// FoldKana が true ならひらがなをカタカナにそろえる（"きーわーど" と "キーワード" を同じ語にする）
+	FoldKana bool
+}
+
+// NormalizedText は正規化したテキストと元のテキストとの対応
+type NormalizedText struct {
+	// Text は正規化したテキスト
+	Text     string
+	original string
+	// starts, ends は Text の各バイトに対応する元のテキストの範囲
+	starts []int
+	ends   []int
+}
+
+// dashes は長音符・ハイフンとして統一する文字
+var dashes = map[rune]bool{
+	'-': true, '‐': true, '‑': true, '‒': true, '–': true, '—': true, '―': true,
+	'−': true, '─': true, '━': true, '﹣': true, 'ー': true,
+}
+
+// longVowelDashes は長音符と見た目が同じ文字（かなの後なら次の文字によらず長音符とみなす）
+// 半角の長音符「ｰ」（U+FF70）は NFKC で「ー」になります
+var longVowelDashes = map[rune]bool{'―': true, '─': true, 'ー': true}
+
+// normalizedRune は正規化後の1文字と元のテキストでの範囲
+type normalizedRune struct {
+	r          rune
+	start, end int
+}
+
+// Normalize はテキストを NFKC で正規化します（全角英数字→半角、半角カタカナ→全角など）
+// あわせて、かなに挟まれたダッシュ類と、かなに続く長音符に似た文字は長音符「ー」に、
+// それ以外のダッシュ類はハイフン「-」にそろえます（"カタカナ-English" のハイフンは区切りのまま）
+func Normalize(text string, opts NormalizeOptions) *NormalizedText {
+	n := &NormalizedText{original: text}
+	var runes []normalizedRune
+	var it norm.Iter
+	it.InitString(norm.NFKC, text)
+	for !it.Done() {
+		start := it.Pos()
+		segment := it.Next()
+		end := it.Pos()
+		for _, r := range string(segment) {
+			runes = append(runes, normalizedRune{r: r, start: start, end: end})
+		}
+	}
+	var b strings.Builder
+	var prev rune
+	for i, nr := range runes {
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1].r
+		}
+		r := foldRune(nr.r, prev, next, opts)
+		prev = r
+		b.WriteRune(r)
+		for j := 0; j < utf8.RuneLen(r); j++ {
+			n.starts = append(n.starts, nr.start)
+			n.ends = append(n.ends, nr.end)
+		}
+	}
+	n.Text = b.String()
+	return n
+}
+
+// NormalizeString は Normalize した文字列を返します
+func NormalizeString(text string, opts NormalizeOptions) string {
+	return Normalize(text, opts).Text
+}
+
+func foldRune(r, prev, next rune, opts NormalizeOptions) rune {
+	if dashes[r] {
+		if isKana(prev) && (longVowelDashes[r] || isKana(next)) {
+			return 'ー'
+		}
+		if r != 'ー' {
+			return '-'
+		}
+	}
+	if opts.FoldKana {
+		return foldKanaRune(r)
+	}
+	return r
+}
+
+// FoldKana はひらがなをカタカナにそろえます（形態素解析後の集計キーに使う）
+func FoldKana(text string) string {
+	return strings.Map(foldKanaRune, text)
+}
+
+func foldKanaRune(r rune) rune {
+	if r >= 'ぁ' && r <= 'ゖ' {
+		return r + ('ァ' - 'ぁ')
+	}
+	return r
+}
+
+func isKana(r rune) bool {
+	return r == 'ー' || unicode.In(r, unicode.Hiragana, unicode.Katakana)
+}
+
+// Original は正規化したテキスト中の語（大文字・小文字は区別しない）の、元のテキストでの表記を返します
+// 最初に現れた位置の表記を返し、正規化で変わらなかった場合や見つからない場合は word をそのまま返します
+func (n *NormalizedText) Original(word string) string {
+	if word == "" || n.starts == nil {
+		return word
+	}
+	idx := strings.Index(n.Text, word)
+	if idx < 0 {
+		if lower := strings.ToLower(n.Text); len(lower) == len(n.Text) {
+			idx = strings.Index(lower, strings.ToLower(word))
+		}
+	}
+	if idx < 0 {
+		return word
+	}
+	end := idx + len(word)
+	original := n.original[n.starts[idx]:n.ends[end-1]]
+	if original == n.Text[idx:end] {
+		return word
+	}
+	return original
+}
@@ -0,0 +1,47 @@
+package language
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		text string
+		opts NormalizeOptions
+		want string
+	}{
+		{"ＡＩとＣｈａｔＧＰＴ", NormalizeOptions{}, "AIとChatGPT"},
+		{"ｷｰﾜｰﾄﾞ分析", NormalizeOptions{}, "キーワード分析"},
+		{"キ—ワ―ド", NormalizeOptions{}, "キーワード"},
+		{"e—mail − 2024", NormalizeOptions{}, "e-mail - 2024"},
+		// かなの後でも次がかなでないハイフン・ダッシュは区切りのまま、長音符に似た文字は長音符にする
+		{"カタカナ-English", NormalizeOptions{}, "カタカナ-English"},
+		{"サーバ— 2024", NormalizeOptions{}, "サーバ- 2024"},
+		{"サーバ― サーバ─", NormalizeOptions{}, "サーバー サーバー"},
+		{"きーわーど", NormalizeOptions{}, "きーわーど"},
+		{"きーわーど", NormalizeOptions{FoldKana: true}, "キーワード"},
+		{"①㈱", NormalizeOptions{}, "1(株)"},
+	}
+	for _, c := range cases {
+		if got := NormalizeString(c.text, c.opts); got != c.want {
+			t.Errorf("NormalizeString(%q, %+v) = %q, want %q", c.text, c.opts, got, c.want)
+		}
+	}
+}
+
+func TestNormalizedTextOriginal(t *testing.T) {
+	n := Normalize("最新のＡＩとｷｰﾜｰﾄﾞ分析 AI", NormalizeOptions{})
+	if got := n.Original("AI"); got != "ＡＩ" {
+		t.Errorf("expected full-width original, got %q", got)
+	}
+	if got := n.Original("ai"); got != "ＡＩ" {
+		t.Errorf("expected case-insensitive match, got %q", got)
+	}
+	if got := n.Original("キーワード"); got != "ｷｰﾜｰﾄﾞ" {
+		t.Errorf("expected half-width original, got %q", got)
+	}
+	if got := n.Original("分析"); got != "分析" {
+		t.Errorf("expected unchanged word, got %q", got)
+	}
+	if got := n.Original("missing"); got != "missing" {
+		t.Errorf("expected word itself when not found, got %q", got)
+	}
+}
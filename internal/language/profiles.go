@@ -0,0 +1,89 @@
+package language
+
+// ラテン文字の言語判定に使う文字 trigram プロファイルの元になるサンプル文
+// 一般的な機能語・語尾を多く含む文章から trigram の出現頻度を求めます
+var profileSamples = map[string]string{
+	English: `The quick development of the internet has changed the way that people work and
+	communicate with each other. Many of the services which we use every day are provided by
+	companies that have been growing very fast. This is one of the reasons why there is a need
+	for more information about how these things are working and what they mean for the future.
+	You can find out more about our products and the latest news from the company on this page.
+	We would like to thank all of our customers for their support and we are looking forward to
+	working with them again. There are many things that should be considered when you are
+	thinking about which of the options is the right one for your business and your family.
+	It was the first time that she had seen the city at night, and she thought it was the most beautiful
+	thing in the world. Open source software is written by developers who share their code with everyone.
+	Getting started with the new system is easy: just download the application, install it on your
+	computer and follow the instructions in the guide. Scaling and managing the deployment of services
+	should be automated, so that teams can spend their time building features instead of fixing problems.`,
+
+	German: `Die schnelle Entwicklung des Internets hat die Art und Weise verändert, wie Menschen
+	arbeiten und miteinander kommunizieren. Viele der Dienste, die wir jeden Tag nutzen, werden von
+	Unternehmen angeboten, die sehr schnell gewachsen sind. Das ist einer der Gründe, warum es mehr
+	Informationen darüber geben muss, wie diese Dinge funktionieren und was sie für die Zukunft
+	bedeuten. Auf dieser Seite finden Sie weitere Informationen über unsere Produkte und die neuesten
+	Nachrichten aus dem Unternehmen. Wir möchten uns bei allen unseren Kunden für ihre Unterstützung
+	bedanken und freuen uns auf die weitere Zusammenarbeit. Es gibt viele Dinge, die man beachten
+	sollte, wenn man überlegt, welche der Möglichkeiten für das eigene Geschäft die richtige ist.
+	Es war das erste Mal, dass sie die Stadt bei Nacht gesehen hatte, und sie dachte, es sei das Schönste
+	auf der Welt. Freie Software wird von Entwicklern geschrieben, die ihren Quellcode mit allen teilen.
+	Der Einstieg in das neue System ist einfach: Laden Sie die Anwendung herunter, installieren Sie sie auf
+	Ihrem Computer und folgen Sie den Anweisungen in der Anleitung. Die Bereitstellung und Verwaltung der
+	Dienste sollte automatisiert werden, damit sich die Teams auf die Entwicklung neuer Funktionen konzentrieren.`,
+
+	French: `Le développement rapide de l'internet a changé la façon dont les gens travaillent et
+	communiquent entre eux. Beaucoup des services que nous utilisons tous les jours sont fournis par
+	des entreprises qui ont connu une croissance très rapide. C'est l'une des raisons pour lesquelles
+	il faut plus d'informations sur le fonctionnement de ces choses et sur ce qu'elles signifient pour
+	l'avenir. Vous trouverez sur cette page plus d'informations sur nos produits et les dernières
+	nouvelles de la société. Nous tenons à remercier tous nos clients pour leur soutien et nous
+	sommes impatients de travailler à nouveau avec eux. Il y a beaucoup de choses à prendre en compte
+	lorsque vous réfléchissez à la meilleure option pour votre entreprise et pour votre famille.
+	C'était la première fois qu'elle voyait la ville la nuit, et elle pensait que c'était la plus belle chose
+	du monde. Les logiciels libres sont écrits par des développeurs qui partagent leur code avec tout le monde.
+	Il est facile de commencer avec le nouveau système : téléchargez l'application, installez-la sur votre
+	ordinateur et suivez les instructions du guide. Le déploiement et la gestion des services devraient être
+	automatisés, afin que les équipes puissent consacrer leur temps au développement de nouvelles fonctionnalités.`,
+
+	Spanish: `El rápido desarrollo de internet ha cambiado la forma en que las personas trabajan y
+	se comunican entre sí. Muchos de los servicios que utilizamos todos los días son ofrecidos por
+	empresas que han crecido muy rápidamente. Esta es una de las razones por las que se necesita más
+	información sobre cómo funcionan estas cosas y lo que significan para el futuro. En esta página
+	puede encontrar más información sobre nuestros productos y las últimas noticias de la empresa.
+	Queremos dar las gracias a todos nuestros clientes por su apoyo y esperamos seguir trabajando
+	con ellos. Hay muchas cosas que se deben tener en cuenta cuando se piensa en cuál de las opciones
+	es la más adecuada para su negocio y para su familia.
+	Era la primera vez que veía la ciudad de noche, y pensó que era lo más bonito del mundo. El software
+	libre está escrito por desarrolladores que comparten su código con todos. Empezar con el nuevo sistema
+	es fácil: descargue la aplicación, instálela en su ordenador y siga las instrucciones de la guía. El
+	despliegue y la gestión de los servicios deberían estar automatizados, para que los equipos puedan
+	dedicar su tiempo a desarrollar nuevas funciones en lugar de arreglar problemas.`,
+
+	Italian: `Il rapido sviluppo di internet ha cambiato il modo in cui le persone lavorano e
+	comunicano tra loro. Molti dei servizi che utilizziamo ogni giorno sono forniti da aziende che
+	sono cresciute molto rapidamente. Questo è uno dei motivi per cui sono necessarie più informazioni
+	su come funzionano queste cose e su cosa significano per il futuro. In questa pagina potete trovare
+	maggiori informazioni sui nostri prodotti e le ultime notizie dell'azienda. Desideriamo ringraziare
+	tutti i nostri clienti per il loro sostegno e siamo lieti di continuare a lavorare con loro. Ci sono
+	molte cose da considerare quando si pensa a quale delle opzioni sia quella giusta per la propria
+	attività e per la propria famiglia.
+	Era la prima volta che vedeva la città di notte, e pensò che fosse la cosa più bella del mondo. Il
+	software libero è scritto da sviluppatori che condividono il loro codice con tutti. Iniziare con il nuovo
+	sistema è semplice: scaricate l'applicazione, installatela sul vostro computer e seguite le istruzioni
+	della guida. La distribuzione e la gestione dei servizi dovrebbero essere automatizzate, in modo che i
+	gruppi di lavoro possano dedicare il loro tempo allo sviluppo di nuove funzionalità.`,
+
+	Portuguese: `O rápido desenvolvimento da internet mudou a forma como as pessoas trabalham e se
+	comunicam umas com as outras. Muitos dos serviços que usamos todos os dias são oferecidos por
+	empresas que cresceram muito rapidamente. Esta é uma das razões pelas quais são necessárias mais
+	informações sobre como estas coisas funcionam e o que significam para o futuro. Nesta página você
+	pode encontrar mais informações sobre os nossos produtos e as últimas notícias da empresa.
+	Gostaríamos de agradecer a todos os nossos clientes pelo seu apoio e esperamos continuar a
+	trabalhar com eles. Há muitas coisas que devem ser consideradas quando se pensa em qual das opções
+	é a mais adequada para o seu negócio e para a sua família.
+	Era a primeira vez que ela via a cidade à noite, e achou que era a coisa mais bonita do mundo. O
+	software livre é escrito por desenvolvedores que compartilham o seu código com todos. Começar com o novo
+	sistema é fácil: baixe o aplicativo, instale-o no seu computador e siga as instruções do guia. A
+	implantação e o gerenciamento dos serviços devem ser automatizados, para que as equipes possam dedicar
+	o seu tempo ao desenvolvimento de novas funcionalidades em vez de resolver problemas.`,
+}
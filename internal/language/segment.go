@@ -0,0 +1,72 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 文字種
+const (
+	// ScriptLatin はラテン文字などのアルファベット（空白で単語を区切る文字）
+	ScriptLatin = "latin"
+	// ScriptCJK は漢字・ひらがな・カタカナ
+	ScriptCJK = "cjk"
+	// ScriptHangul はハングル
+	ScriptHangul = "hangul"
+)
+
+// ScriptRun は同じ文字種が続く区間
+type ScriptRun struct {
+	Script string
+	Text   string
+}
+
+// SplitScriptRuns はテキストを文字種ごとの区間に分割します（例: "Kubernetes クラスタの構築" → "Kubernetes", "クラスタの構築"）
+// 数字・記号・空白などの文字種を持たない文字は直前の区間（先頭なら最初の区間）に含め、前後の空白は除きます
+func SplitScriptRuns(text string) []ScriptRun {
+	var runs []ScriptRun
+	var current strings.Builder
+	script, pending := "", ""
+	flush := func() {
+		if s := strings.TrimSpace(current.String()); s != "" && script != "" {
+			runs = append(runs, ScriptRun{Script: script, Text: s})
+		}
+		current.Reset()
+	}
+	for _, r := range text {
+		s := scriptOf(r)
+		switch {
+		case s == "":
+			if script == "" {
+				pending += string(r)
+				continue
+			}
+		case script == "":
+			script = s
+			current.WriteString(pending)
+			pending = ""
+		case s != script:
+			flush()
+			script = s
+		}
+		current.WriteRune(r)
+	}
+	flush()
+	return runs
+}
+
+// scriptOf は文字の文字種を返します（文字種を持たない文字は空文字）
+func scriptOf(r rune) string {
+	switch {
+	case unicode.Is(unicode.Hangul, r):
+		return ScriptHangul
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+		return ScriptCJK
+	case unicode.In(r, unicode.Common, unicode.Inherited):
+		// 長音符「ー」や結合文字など複数の文字種で使われる文字
+		return ""
+	case unicode.IsLetter(r):
+		return ScriptLatin
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package language
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitScriptRuns(t *testing.T) {
+	cases := []struct {
+		text string
+		want []ScriptRun
+	}{
+		{"Kubernetes クラスタの構築", []ScriptRun{{ScriptLatin, "Kubernetes"}, {ScriptCJK, "クラスタの構築"}}},
+		{"第3章: Go言語とDocker入門", []ScriptRun{{ScriptCJK, "第3章:"}, {ScriptLatin, "Go"}, {ScriptCJK, "言語と"}, {ScriptLatin, "Docker"}, {ScriptCJK, "入門"}}},
+		{"「サーバー」の設定 2024", []ScriptRun{{ScriptCJK, "「サーバー」の設定 2024"}}},
+		{"서울에서 Seoul", []ScriptRun{{ScriptHangul, "서울에서"}, {ScriptLatin, "Seoul"}}},
+		{"Café crème", []ScriptRun{{ScriptLatin, "Café crème"}}},
+		{"123 !?", nil},
+	}
+	for _, c := range cases {
+		if got := SplitScriptRuns(c.text); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SplitScriptRuns(%q) = %+v, want %+v", c.text, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// テキストの出現元
+const (
+	SourceTitle  = "title"
+	SourceMeta   = "meta"
+	SourceH1     = "h1"
+	SourceH2     = "h2"
+	SourceBody   = "body"
+	SourceAlt    = "alt"
+	SourceAnchor = "anchor"
+)
+
+// TextBlock はページ内のひとまとまりのテキストとその出現元
+type TextBlock struct {
+	Source string
+	Text   string
+	// DOMPath は要素の位置（例: "html > body > div:nth-of-type(2) > h1"）
+	DOMPath string
+	// Offset はページ全体のテキスト（ブロックを文書順に連結したもの）での開始文字位置
+	Offset int
+}
+
+// skipTags はテキストとして扱わない要素
+var skipTags = map[string]bool{"script": true, "style": true, "noscript": true, "template": true}
+
+// FetchTextBlocks はタイトル・メタタグ・本文のテキストを出現元ごとのブロックとして文書順に返します
+// h1, h2, a 要素はまとめて1ブロック、img の alt 属性も1ブロックとし、それ以外の本文テキストは body とします
+func (h *HTMLDocument) FetchTextBlocks() []TextBlock {
+	var blocks []TextBlock
+	offset := 0
+	add := func(source, text, path string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		blocks = append(blocks, TextBlock{Source: source, Text: text, DOMPath: path, Offset: offset})
+		offset += utf8.RuneCountInString(text) + 1
+	}
+
+	if title := h.Doc.Find("title").First(); title.Length() > 0 {
+		add(SourceTitle, title.Text(), domPath(title.Get(0)))
+	}
+	h.Doc.Find("meta").Each(func(i int, s *goquery.Selection) {
+		name := strings.ToLower(s.AttrOr("name", s.AttrOr("property", "")))
+		if name == "description" || name == "keywords" || name == "og:description" {
+			add(SourceMeta, s.AttrOr("content", ""), domPath(s.Get(0)))
+		}
+	})
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.Type {
+			case html.TextNode:
+				if n.Type == html.ElementNode && n.Data != "title" {
+					add(SourceBody, c.Data, domPath(n))
+				}
+			case html.ElementNode:
+				switch {
+				case skipTags[c.Data] || c.Data == "head":
+					continue
+				case c.Data == "h1":
+					add(SourceH1, goquery.NewDocumentFromNode(c).Text(), domPath(c))
+				case c.Data == "h2":
+					add(SourceH2, goquery.NewDocumentFromNode(c).Text(), domPath(c))
+				case c.Data == "a":
+					add(SourceAnchor, goquery.NewDocumentFromNode(c).Text(), domPath(c))
+				case c.Data == "img":
+					for _, attr := range c.Attr {
+						if attr.Key == "alt" {
+							add(SourceAlt, attr.Val, domPath(c))
+						}
+					}
+				default:
+					walk(c)
+				}
+			}
+		}
+	}
+	if root := h.Doc.Get(0); root != nil {
+		walk(root)
+	}
+	return blocks
+}
+
+// domPath は要素の位置を CSS セレクタ風の文字列で返します（同名の兄弟要素がある場合は :nth-of-type を付与）
+func domPath(n *html.Node) string {
+	var parts []string
+	for ; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		index, total := 0, 0
+		if n.Parent != nil {
+			for s := n.Parent.FirstChild; s != nil; s = s.NextSibling {
+				if s.Type == html.ElementNode && s.Data == n.Data {
+					total++
+					if s == n {
+						index = total
+					}
+				}
+			}
+		}
+		part := n.Data
+		if total > 1 {
+			part = fmt.Sprintf("%s:nth-of-type(%d)", n.Data, index)
+		}
+		parts = append([]string{part}, parts...)
+	}
+	return strings.Join(parts, " > ")
+}
@@ -31,3 +31,27 @@ func (h *HTMLDocument) FetchTags(tag string) []string {
 	})
 	return result
 }
+
+// Heading は見出し要素のレベル（1〜6）とテキスト
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// FetchHeadings は h1〜h6 の見出しを文書順に返します（空の見出しは除く）
+func (h *HTMLDocument) FetchHeadings() []Heading {
+	var result []Heading
+	h.Doc.Find("h1, h2, h3, h4, h5, h6").Each(func(i int, s *goquery.Selection) {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		if text == "" {
+			return
+		}
+		result = append(result, Heading{Level: int(goquery.NodeName(s)[1] - '0'), Text: text})
+	})
+	return result
+}
+
+// FetchHTMLLang は <html lang> 属性の値を返します（未指定なら空文字）
+func (h *HTMLDocument) FetchHTMLLang() string {
+	return strings.TrimSpace(h.Doc.Find("html").First().AttrOr("lang", ""))
+}
@@ -26,3 +26,57 @@ func TestParseHTMLDocument_InvalidHTML(t *testing.T) {
 		t.Error("goquery should not error on empty string")
 	}
 }
+
+func TestFetchTextBlocks(t *testing.T) {
+	html := `<html><head><title>Title</title><meta name="description" content="Desc"></head>
+	<body><div><h1>Heading <b>One</b></h1><p>Body <a href="/x">Link</a></p><img src="a.png" alt="Picture"><script>var x;</script></div></body></html>`
+	doc, err := ParseHTMLDocument(html)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blocks := doc.FetchTextBlocks()
+	expected := []TextBlock{
+		{Source: SourceTitle, Text: "Title", DOMPath: "html > head > title", Offset: 0},
+		{Source: SourceMeta, Text: "Desc", DOMPath: "html > head > meta", Offset: 6},
+		{Source: SourceH1, Text: "Heading One", DOMPath: "html > body > div > h1", Offset: 11},
+		{Source: SourceBody, Text: "Body", DOMPath: "html > body > div > p", Offset: 23},
+		{Source: SourceAnchor, Text: "Link", DOMPath: "html > body > div > p > a", Offset: 28},
+		{Source: SourceAlt, Text: "Picture", DOMPath: "html > body > div > img", Offset: 33},
+	}
+	if len(blocks) != len(expected) {
+		t.Fatalf("expected %d blocks, got %+v", len(expected), blocks)
+	}
+	for i := range expected {
+		if blocks[i] != expected[i] {
+			t.Errorf("block %d: expected %+v, got %+v", i, expected[i], blocks[i])
+		}
+	}
+}
+
+func TestFetchHeadings(t *testing.T) {
+	doc, err := ParseHTMLDocument(`<html><body><h2>B</h2><h1> A  <i>x</i></h1><h6></h6><h5>E</h5></body></html>`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	headings := doc.FetchHeadings()
+	expected := []Heading{{2, "B"}, {1, "A x"}, {5, "E"}}
+	if len(headings) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, headings)
+	}
+	for i := range expected {
+		if headings[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], headings[i])
+		}
+	}
+}
+
+func TestFetchHTMLLang(t *testing.T) {
+	doc, _ := ParseHTMLDocument(`<html lang=" ja-JP "><body>テスト</body></html>`)
+	if got := doc.FetchHTMLLang(); got != "ja-JP" {
+		t.Errorf("expected ja-JP, got %q", got)
+	}
+	doc, _ = ParseHTMLDocument(`<html><body>Test</body></html>`)
+	if got := doc.FetchHTMLLang(); got != "" {
+		t.Errorf("expected empty lang, got %q", got)
+	}
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Anchor はリンク（a要素）のテキストとリンク先
+type Anchor struct {
+	Text string
+	Href string
+	// Internal はリンク先がページと同じホスト（または相対リンク）の場合に true
+	Internal bool
+}
+
+// FetchImageAlts は img 要素の alt 属性をすべて返します（空の alt は除く）
+func (h *HTMLDocument) FetchImageAlts() []string {
+	var result []string
+	h.Doc.Find("img[alt]").Each(func(i int, s *goquery.Selection) {
+		if alt := strings.Join(strings.Fields(s.AttrOr("alt", "")), " "); alt != "" {
+			result = append(result, alt)
+		}
+	})
+	return result
+}
+
+// FetchImagesWithoutAlt は alt 属性のない img 要素の src を返します（alt="" は装飾画像として除く）
+func (h *HTMLDocument) FetchImagesWithoutAlt() []string {
+	var result []string
+	h.Doc.Find("img").Each(func(i int, s *goquery.Selection) {
+		if _, ok := s.Attr("alt"); !ok {
+			result = append(result, s.AttrOr("src", ""))
+		}
+	})
+	return result
+}
+
+// FetchFigcaptions は figcaption 要素のテキストをすべて返します
+func (h *HTMLDocument) FetchFigcaptions() []string {
+	var result []string
+	h.Doc.Find("figcaption").Each(func(i int, s *goquery.Selection) {
+		if text := strings.Join(strings.Fields(s.Text()), " "); text != "" {
+			result = append(result, text)
+		}
+	})
+	return result
+}
+
+// FetchAnchors は a 要素のテキストとリンク先を返します（テキストが空のリンクは除く）
+// pageURL はページ自身のURLで、内部リンクかどうかの判定に使います
+func (h *HTMLDocument) FetchAnchors(pageURL string) []Anchor {
+	base, _ := url.Parse(pageURL)
+	var result []Anchor
+	h.Doc.Find("a").Each(func(i int, s *goquery.Selection) {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		if text == "" {
+			return
+		}
+		href := s.AttrOr("href", "")
+		result = append(result, Anchor{Text: text, Href: href, Internal: isInternalLink(href, base)})
+	})
+	return result
+}
+
+// isInternalLink はリンク先がページと同じホストか判定します（相対リンク・フラグメントは内部リンク）
+func isInternalLink(href string, base *url.URL) bool {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return false
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		// mailto:, javascript: など
+		return false
+	}
+	if u.Host == "" {
+		return true
+	}
+	if base == nil || base.Host == "" {
+		return false
+	}
+	return strings.EqualFold(strings.TrimPrefix(u.Hostname(), "www."), strings.TrimPrefix(base.Hostname(), "www."))
+}
@@ -0,0 +1,52 @@
+package parser
+
+import "testing"
+
+func TestFetchImageAltsAndFigcaptions(t *testing.T) {
+	html := `<html><body><figure><img src="a.jpg" alt="Red  running shoes"><figcaption>Trail shoes</figcaption></figure><img src="b.jpg" alt=""><img src="c.jpg"></body></html>`
+	doc, err := ParseHTMLDocument(html)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	alts := doc.FetchImageAlts()
+	if len(alts) != 1 || alts[0] != "Red running shoes" {
+		t.Errorf("expected [Red running shoes], got %v", alts)
+	}
+	captions := doc.FetchFigcaptions()
+	if len(captions) != 1 || captions[0] != "Trail shoes" {
+		t.Errorf("expected [Trail shoes], got %v", captions)
+	}
+	missing := doc.FetchImagesWithoutAlt()
+	if len(missing) != 1 || missing[0] != "c.jpg" {
+		t.Errorf("expected [c.jpg], got %v", missing)
+	}
+}
+
+func TestFetchAnchors(t *testing.T) {
+	html := `<html><body>
+	<a href="/products">Products</a>
+	<a href="https://www.example.com/about">About</a>
+	<a href="https://other.example.org/">Partner</a>
+	<a href="mailto:info@example.com">Mail</a>
+	<a href="/empty"></a>
+	</body></html>`
+	doc, err := ParseHTMLDocument(html)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	anchors := doc.FetchAnchors("https://example.com/")
+	expected := []Anchor{
+		{Text: "Products", Href: "/products", Internal: true},
+		{Text: "About", Href: "https://www.example.com/about", Internal: true},
+		{Text: "Partner", Href: "https://other.example.org/", Internal: false},
+		{Text: "Mail", Href: "mailto:info@example.com", Internal: false},
+	}
+	if len(anchors) != len(expected) {
+		t.Fatalf("expected %d anchors, got %+v", len(expected), anchors)
+	}
+	for i := range expected {
+		if anchors[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], anchors[i])
+		}
+	}
+}
@@ -39,3 +39,36 @@ func (h *HTMLDocument) FetchMetaTags() map[string]string {
 	})
 	return result
 }
+
+// FetchRobotsDirectives は meta robots / googlebot の指示（"noindex" など）を小文字で返します
+func (h *HTMLDocument) FetchRobotsDirectives() []string {
+	var result []string
+	h.Doc.Find("meta[name]").Each(func(i int, s *goquery.Selection) {
+		switch strings.ToLower(s.AttrOr("name", "")) {
+		case "robots", "googlebot":
+			result = append(result, ParseRobotsDirectives(s.AttrOr("content", ""))...)
+		}
+	})
+	return result
+}
+
+// ParseRobotsDirectives はカンマ区切りの robots 指示（meta robots・X-Robots-Tag の値）を小文字で分割します
+// X-Robots-Tag の "googlebot: noindex" のようなユーザーエージェント指定は取り除きます
+func ParseRobotsDirectives(content string) []string {
+	var result []string
+	for _, d := range strings.Split(content, ",") {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if i := strings.Index(d, ":"); i >= 0 && !hasRobotsValue(d[:i]) {
+			d = strings.TrimSpace(d[i+1:])
+		}
+		if d != "" {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// hasRobotsValue は "max-snippet:20" のように値を取る robots 指示の名前なら true を返します
+func hasRobotsValue(name string) bool {
+	return strings.HasPrefix(name, "max-") || name == "unavailable_after"
+}
@@ -33,3 +33,24 @@ func TestFetchMetaTags(t *testing.T) {
 		t.Errorf("expected sitename, got %s", meta["og:site_name"])
 	}
 }
+
+func TestFetchRobotsDirectives(t *testing.T) {
+	html := `<html><head>
+	<meta name="ROBOTS" content="NoIndex, follow">
+	<meta name="googlebot" content="nosnippet">
+	<meta name="description" content="desc">
+	</head></html>`
+	doc, err := ParseHTMLDocument(html)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := doc.FetchRobotsDirectives()
+	expected := []string{"noindex", "follow", "nosnippet"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	got = ParseRobotsDirectives("googlebot: noindex, max-snippet:20, otherbot: max-image-preview:large")
+	if strings.Join(got, ",") != "noindex,max-snippet:20,max-image-preview:large" {
+		t.Errorf("expected [noindex max-snippet:20 max-image-preview:large], got %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package scoring
+
+import (
+	"math"
+)
+
+// 正規化方式（config.Config.Normalization / --normalization で指定）
+const (
+	// NormalizationMax は最大スコアを 1 とする比率
+	NormalizationMax = "max"
+	// NormalizationSum は全候補のスコア合計に対する比率
+	NormalizationSum = "sum"
+	// NormalizationSoftmax は全候補のスコア（最大スコアで割って SoftmaxTemperature で調整）に対する softmax
+	NormalizationSoftmax = "softmax"
+)
+
+// SoftmaxTemperature は softmax の温度（最大スコアを 1 とした値をこの値で割ってから softmax を取る）
+// 生のスコア（重みの合計で数十になる）のままでは1位がほぼ 1、それ以外がほぼ 0 になるため、
+// 最大スコアの語と 0 点の語の比が e^5（約150倍）に収まるようにする
+const SoftmaxTemperature = 0.2
+
+// Normalize は ranked の各キーワードに 0〜1 の正規化スコアと全体に占める割合（%）を設定します
+// 最大値・合計などの統計は scoreMap（上位 N 件に絞る前の全候補）から計算するため、
+// ページごとに件数やスコアの規模が異なっても比較できます
+func Normalize(ranked []KeywordWithScore, scoreMap map[string]int, strategy string) []KeywordWithScore {
+	if len(ranked) == 0 || len(scoreMap) == 0 {
+		return ranked
+	}
+	maxScore, total := math.Inf(-1), 0.0
+	for _, v := range scoreMap {
+		maxScore = math.Max(maxScore, float64(v))
+		total += float64(v)
+	}
+	// softmax の指数（最大スコアの語が 0 になるようにずらしてオーバーフローを防ぐ）
+	softmaxExp := func(score float64) float64 {
+		if maxScore <= 0 {
+			return 1
+		}
+		return math.Exp((score/maxScore - 1) / SoftmaxTemperature)
+	}
+	expTotal := 0.0
+	if strategy == NormalizationSoftmax {
+		for _, v := range scoreMap {
+			expTotal += softmaxExp(float64(v))
+		}
+	}
+
+	for i := range ranked {
+		score := float64(ranked[i].Score)
+		var normalized float64
+		switch strategy {
+		case NormalizationSum:
+			if total > 0 {
+				normalized = score / total
+			}
+		case NormalizationSoftmax:
+			normalized = softmaxExp(score) / expTotal
+		default:
+			if maxScore > 0 {
+				normalized = score / maxScore
+			}
+		}
+		ranked[i].NormalizedScore = round(normalized, 4)
+		if total > 0 {
+			ranked[i].Percentage = round(score/total*100, 2)
+		}
+	}
+	return ranked
+}
+
+// round は小数点以下 digits 桁に四捨五入します（JSON出力の安定化のため）
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
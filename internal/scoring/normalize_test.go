@@ -0,0 +1,54 @@
+package scoring
+
+import "testing"
+
+func TestNormalize_Max(t *testing.T) {
+	scoreMap := map[string]int{"go": 10, "java": 5, "rust": 5}
+	ranked := RankKeywordsByScore(scoreMap, map[string]string{}, 2)
+	ranked = Normalize(ranked, scoreMap, NormalizationMax)
+	if ranked[0].NormalizedScore != 1 || ranked[1].NormalizedScore != 0.5 {
+		t.Errorf("unexpected normalized scores: %+v", ranked)
+	}
+	// 割合は上位に絞る前の全候補の合計(20)に対して計算される
+	if ranked[0].Percentage != 50 || ranked[1].Percentage != 25 {
+		t.Errorf("unexpected percentages: %+v", ranked)
+	}
+}
+
+func TestNormalize_Sum(t *testing.T) {
+	scoreMap := map[string]int{"go": 3, "java": 1}
+	ranked := Normalize(RankKeywordsByScore(scoreMap, map[string]string{}, 0), scoreMap, NormalizationSum)
+	if ranked[0].NormalizedScore != 0.75 || ranked[1].NormalizedScore != 0.25 {
+		t.Errorf("unexpected normalized scores: %+v", ranked)
+	}
+}
+
+func TestNormalize_Softmax(t *testing.T) {
+	scoreMap := map[string]int{"go": 2, "java": 2}
+	ranked := Normalize(RankKeywordsByScore(scoreMap, map[string]string{}, 0), scoreMap, NormalizationSoftmax)
+	if ranked[0].NormalizedScore != 0.5 || ranked[1].NormalizedScore != 0.5 {
+		t.Errorf("unexpected normalized scores: %+v", ranked)
+	}
+}
+
+func TestNormalize_SoftmaxSpreadsTypicalScores(t *testing.T) {
+	// 重みの合計で数十になる一般的なページのスコア
+	scoreMap := map[string]int{"kubernetes": 42, "cluster": 35, "node": 21, "pod": 12, "service": 5}
+	ranked := Normalize(RankKeywordsByScore(scoreMap, map[string]string{}, 0), scoreMap, NormalizationSoftmax)
+	if ranked[0].NormalizedScore >= 0.9 {
+		t.Errorf("expected the top keyword not to take almost all of the mass, got %+v", ranked)
+	}
+	if ranked[1].NormalizedScore < 0.1 {
+		t.Errorf("expected the second keyword to keep a comparable score, got %+v", ranked)
+	}
+	sum := 0.0
+	for i, k := range ranked {
+		sum += k.NormalizedScore
+		if i > 0 && k.NormalizedScore > ranked[i-1].NormalizedScore {
+			t.Errorf("expected scores in rank order, got %+v", ranked)
+		}
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("expected softmax scores to sum to 1, got %v", sum)
+	}
+}
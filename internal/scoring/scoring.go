@@ -1,26 +1,66 @@
 package scoring
 
 import (
+	"math"
 	"sort"
+
+	"github.com/xshoji/go-site-keyword/pkg/types"
 )
 
-type KeywordWithScore struct {
-	Keyword string
-	Score   int
+// KeywordWithScore は types.KeywordWithScore の別名（Scorer 実装間で共有するため）
+type KeywordWithScore = types.KeywordWithScore
+
+// FrequencyScorer は重み付き出現頻度の合計でランク付けする既定の Scorer
+type FrequencyScorer struct{}
+
+// Rank は types.KeywordScorer を満たします
+func (FrequencyScorer) Rank(scoreMap map[string]int, originalMap map[string]string, limit int) []KeywordWithScore {
+	return RankKeywordsByScore(scoreMap, originalMap, limit)
 }
 
-// RankKeywordsByScore はキーワードをスコア順にランク付けします
+// KeywordOrder はスコアが同点のキーワードの並び順を決める情報
+type KeywordOrder struct {
+	// FirstPosition は文書内で最初に現れた位置（小さいほど先）
+	FirstPosition int
+	// SourcePriority は最初に現れた出現元の優先度（小さいほど先, タイトル=0）
+	SourcePriority int
+}
+
+// RankKeywordsByScore はキーワードをスコア順にランク付けします（同点は辞書順）
 func RankKeywordsByScore(scoreMap map[string]int, originalMap map[string]string, limit int) []KeywordWithScore {
+	return RankKeywordsByScoreOrdered(scoreMap, originalMap, nil, limit)
+}
+
+// RankKeywordsByScoreOrdered はキーワードをスコア順にランク付けします
+// 同点の場合は文書内の初出位置、出現元の優先度、キーの辞書順の順で並べるため、
+// 実行ごとに結果が変わりません（orderMap にないキーは末尾扱い）
+func RankKeywordsByScoreOrdered(scoreMap map[string]int, originalMap map[string]string, orderMap map[string]KeywordOrder, limit int) []KeywordWithScore {
 	type kv struct {
 		Key   string
 		Value int
+		Order KeywordOrder
 	}
+	unknown := KeywordOrder{FirstPosition: math.MaxInt, SourcePriority: math.MaxInt}
 	var sorted []kv
 	for k, v := range scoreMap {
-		sorted = append(sorted, kv{k, v})
+		order, ok := orderMap[k]
+		if !ok {
+			order = unknown
+		}
+		sorted = append(sorted, kv{k, v, order})
 	}
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Value > sorted[j].Value
+		a, b := sorted[i], sorted[j]
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+		if a.Order.FirstPosition != b.Order.FirstPosition {
+			return a.Order.FirstPosition < b.Order.FirstPosition
+		}
+		if a.Order.SourcePriority != b.Order.SourcePriority {
+			return a.Order.SourcePriority < b.Order.SourcePriority
+		}
+		return a.Key < b.Key
 	})
 
 	var result []KeywordWithScore
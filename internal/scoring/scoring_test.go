@@ -34,3 +34,31 @@ func TestRankKeywordsByScore_LimitZero(t *testing.T) {
 		t.Errorf("expected 2 results, got %d", len(result))
 	}
 }
+
+func TestRankKeywordsByScoreOrdered_TieBreak(t *testing.T) {
+	scoreMap := map[string]int{"delta": 5, "alpha": 5, "charlie": 5, "bravo": 5, "echo": 9}
+	orderMap := map[string]KeywordOrder{
+		"delta":   {FirstPosition: 1, SourcePriority: 0},
+		"charlie": {FirstPosition: 3, SourcePriority: 1},
+		"bravo":   {FirstPosition: 3, SourcePriority: 0},
+	}
+	expected := []string{"echo", "delta", "bravo", "charlie", "alpha"}
+	for run := 0; run < 20; run++ {
+		result := RankKeywordsByScoreOrdered(scoreMap, map[string]string{}, orderMap, 0)
+		for i, kw := range result {
+			if kw.Keyword != expected[i] {
+				t.Fatalf("run %d: expected %v, got %+v", run, expected, result)
+			}
+		}
+	}
+}
+
+func TestRankKeywordsByScore_LexicalTieBreak(t *testing.T) {
+	scoreMap := map[string]int{"b": 1, "c": 1, "a": 1}
+	for run := 0; run < 20; run++ {
+		result := RankKeywordsByScore(scoreMap, map[string]string{}, 2)
+		if result[0].Keyword != "a" || result[1].Keyword != "b" {
+			t.Fatalf("run %d: expected [a b], got %+v", run, result)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package scoring
+
+import (
+	"math"
+	"sort"
+)
+
+// スコアリングアルゴリズム名（config.Config.Algorithm / --algorithm で指定）
+const (
+	AlgorithmFrequency = "frequency"
+	AlgorithmTextRank  = "textrank"
+)
+
+// TextRank のデフォルトパラメータ
+const (
+	DefaultTextRankWindow     = 4
+	DefaultTextRankDamping    = 0.85
+	textRankMaxIterations     = 100
+	textRankConvergenceThresh = 1e-4
+)
+
+// TextRank は候補語の並び（segments の各要素が1文・1見出しなどの区切り）から
+// window 語以内に共起する語同士を辺とするグラフを作り、PageRank で各語の重要度を計算します
+// 区切りをまたいだ共起は数えません。戻り値の平均はおおよそ 1 になります
+func TextRank(segments [][]string, window int, damping float64) map[string]float64 {
+	if window < 2 {
+		window = 2
+	}
+	if damping <= 0 || damping >= 1 {
+		damping = DefaultTextRankDamping
+	}
+
+	// 出現順にノード番号を振る（反復順を固定して結果を決定的にする）
+	index := map[string]int{}
+	var terms []string
+	for _, seg := range segments {
+		for _, t := range seg {
+			if _, ok := index[t]; !ok {
+				index[t] = len(terms)
+				terms = append(terms, t)
+			}
+		}
+	}
+	if len(terms) == 0 {
+		return map[string]float64{}
+	}
+
+	// 共起回数を重みとする無向グラフ
+	weights := make([]map[int]float64, len(terms))
+	for i := range weights {
+		weights[i] = map[int]float64{}
+	}
+	for _, seg := range segments {
+		for i := range seg {
+			for j := i + 1; j < len(seg) && j < i+window; j++ {
+				a, b := index[seg[i]], index[seg[j]]
+				if a == b {
+					continue
+				}
+				weights[a][b]++
+				weights[b][a]++
+			}
+		}
+	}
+
+	type edge struct {
+		to     int
+		weight float64
+	}
+	adjacency := make([][]edge, len(terms))
+	outSum := make([]float64, len(terms))
+	for i, m := range weights {
+		for j, w := range m {
+			adjacency[i] = append(adjacency[i], edge{j, w})
+			outSum[i] += w
+		}
+		sort.Slice(adjacency[i], func(x, y int) bool { return adjacency[i][x].to < adjacency[i][y].to })
+	}
+
+	ranks := make([]float64, len(terms))
+	for i := range ranks {
+		ranks[i] = 1
+	}
+	next := make([]float64, len(terms))
+	for iter := 0; iter < textRankMaxIterations; iter++ {
+		maxDelta := 0.0
+		for i := range terms {
+			sum := 0.0
+			for _, e := range adjacency[i] {
+				sum += e.weight / outSum[e.to] * ranks[e.to]
+			}
+			next[i] = (1 - damping) + damping*sum
+			maxDelta = math.Max(maxDelta, math.Abs(next[i]-ranks[i]))
+		}
+		ranks, next = next, ranks
+		if maxDelta < textRankConvergenceThresh {
+			break
+		}
+	}
+
+	result := make(map[string]float64, len(terms))
+	for i, t := range terms {
+		result[t] = ranks[i]
+	}
+	return result
+}
+
+// RanksToScores は浮動小数のランクを RankKeywordsByScore で扱える整数スコア（×100, 四捨五入）に変換します
+func RanksToScores(ranks map[string]float64) map[string]int {
+	scores := make(map[string]int, len(ranks))
+	for k, r := range ranks {
+		scores[k] = int(math.Round(r * 100))
+	}
+	return scores
+}
@@ -0,0 +1,38 @@
+package scoring
+
+import "testing"
+
+func TestTextRank_HubTermRanksHighest(t *testing.T) {
+	// "kubernetes" は全ての区切りで他の語と共起する
+	segments := [][]string{
+		{"kubernetes", "cluster"},
+		{"kubernetes", "deploy"},
+		{"kubernetes", "helm"},
+		{"monitoring", "kubernetes"},
+	}
+	ranks := TextRank(segments, DefaultTextRankWindow, DefaultTextRankDamping)
+	for term, r := range ranks {
+		if term != "kubernetes" && r >= ranks["kubernetes"] {
+			t.Errorf("expected kubernetes to outrank %s (%f >= %f)", term, r, ranks["kubernetes"])
+		}
+	}
+}
+
+func TestTextRank_WindowDoesNotCrossSegments(t *testing.T) {
+	segments := [][]string{{"alpha"}, {"beta"}}
+	ranks := TextRank(segments, DefaultTextRankWindow, DefaultTextRankDamping)
+	if len(ranks) != 2 {
+		t.Fatalf("expected 2 terms, got %d", len(ranks))
+	}
+	// 孤立ノードは (1 - d) のみ
+	if ranks["alpha"] != ranks["beta"] {
+		t.Errorf("expected isolated terms to have equal rank, got %v", ranks)
+	}
+}
+
+func TestRanksToScores(t *testing.T) {
+	scores := RanksToScores(map[string]float64{"go": 1.234, "java": 0.5})
+	if scores["go"] != 123 || scores["java"] != 50 {
+		t.Errorf("unexpected scores: %v", scores)
+	}
+}
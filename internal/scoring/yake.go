@@ -0,0 +1,169 @@
+package scoring
+
+import (
+	"math"
+	"sort"
+	"unicode"
+)
+
+// AlgorithmYake は YAKE によるスコアリングを表します
+const AlgorithmYake = "yake"
+
+// DefaultYakeWindow は YAKE の文脈（左右の共起語）を数える語数の幅
+const DefaultYakeWindow = 1
+
+// YakeToken は YAKE に渡す1語分の情報
+type YakeToken struct {
+	// Key は正規化後のキー（同じ語の異なる表記をまとめる）
+	Key string
+	// Surface は元の表記（大文字・小文字の判定に使う）
+	Surface string
+	// Candidate が false の語（ストップワードなど）は文脈としてのみ使われ、スコアは付きません
+	Candidate bool
+}
+
+// yakeStats は1語分の集計値
+type yakeStats struct {
+	tf        float64
+	tfUpper   float64
+	tfAcronym float64
+	sentences []int
+	left      map[string]int
+	right     map[string]int
+	leftSum   float64
+	rightSum  float64
+}
+
+// Yake は YAKE (Yet Another Keyword Extractor) の単語スコアを計算します
+// 入力は文ごとのトークン列で、大文字表記・出現位置・頻度・文脈の多様性・文への分散を特徴量とします
+// 戻り値は YAKE の S(t) で、値が小さいほど重要な語です
+func Yake(sentences [][]YakeToken, window int) map[string]float64 {
+	if window < 1 {
+		window = DefaultYakeWindow
+	}
+	stats := map[string]*yakeStats{}
+	get := func(key string) *yakeStats {
+		s, ok := stats[key]
+		if !ok {
+			s = &yakeStats{left: map[string]int{}, right: map[string]int{}}
+			stats[key] = s
+		}
+		return s
+	}
+
+	for si, sentence := range sentences {
+		for i, tok := range sentence {
+			if !tok.Candidate {
+				continue
+			}
+			s := get(tok.Key)
+			s.tf++
+			if isAcronym(tok.Surface) {
+				s.tfAcronym++
+			} else if i > 0 && startsUpper(tok.Surface) {
+				s.tfUpper++
+			}
+			if len(s.sentences) == 0 || s.sentences[len(s.sentences)-1] != si {
+				s.sentences = append(s.sentences, si)
+			}
+			for j := i - window; j < i; j++ {
+				if j >= 0 {
+					s.left[sentence[j].Key]++
+					s.leftSum++
+				}
+			}
+			for j := i + 1; j <= i+window && j < len(sentence); j++ {
+				s.right[sentence[j].Key]++
+				s.rightSum++
+			}
+		}
+	}
+	if len(stats) == 0 {
+		return map[string]float64{}
+	}
+
+	// 候補語の頻度の平均・標準偏差・最大値
+	var sum, maxTF float64
+	for _, s := range stats {
+		sum += s.tf
+		maxTF = math.Max(maxTF, s.tf)
+	}
+	mean := sum / float64(len(stats))
+	var variance float64
+	for _, s := range stats {
+		variance += (s.tf - mean) * (s.tf - mean)
+	}
+	std := math.Sqrt(variance / float64(len(stats)))
+
+	result := make(map[string]float64, len(stats))
+	for key, s := range stats {
+		tCase := math.Max(s.tfUpper, s.tfAcronym) / (1 + math.Log(s.tf))
+		tPos := math.Log(math.Log(3 + median(s.sentences)))
+		tFreq := s.tf / (mean + std)
+		dl, dr := 0.0, 0.0
+		if s.leftSum > 0 {
+			dl = float64(len(s.left)) / s.leftSum
+		}
+		if s.rightSum > 0 {
+			dr = float64(len(s.right)) / s.rightSum
+		}
+		tRel := 1 + (dl+dr)*(s.tf/maxTF)
+		tSent := float64(len(s.sentences)) / float64(len(sentences))
+		result[key] = (tRel * tPos) / (tCase + tFreq/tRel + tSent/tRel)
+	}
+	return result
+}
+
+// YakeToRanks は YAKE の S(t)（小さいほど重要）を 0〜1 の値（大きいほど重要, 最良の語が1）に変換します
+func YakeToRanks(scores map[string]float64) map[string]float64 {
+	minScore := math.Inf(1)
+	for _, s := range scores {
+		if s > 0 {
+			minScore = math.Min(minScore, s)
+		}
+	}
+	ranks := make(map[string]float64, len(scores))
+	for k, s := range scores {
+		if s <= 0 {
+			ranks[k] = 1
+			continue
+		}
+		ranks[k] = minScore / s
+	}
+	return ranks
+}
+
+func median(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return float64(sorted[mid])
+}
+
+// startsUpper は先頭が大文字か判定
+func startsUpper(s string) bool {
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// isAcronym は2文字以上の英大文字のみ（数字を含んでもよい）か判定
+func isAcronym(s string) bool {
+	letters := 0
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsUpper(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
@@ -0,0 +1,42 @@
+package scoring
+
+import "testing"
+
+func yakeSentence(words ...string) []YakeToken {
+	var tokens []YakeToken
+	for _, w := range words {
+		tokens = append(tokens, YakeToken{Key: w, Surface: w, Candidate: w != "the" && w != "a"})
+	}
+	return tokens
+}
+
+func TestYake_FrequentEarlyTermScoresLower(t *testing.T) {
+	sentences := [][]YakeToken{
+		yakeSentence("Kubernetes", "cluster", "guide"),
+		yakeSentence("Deploy", "the", "Kubernetes", "cluster"),
+		yakeSentence("a", "random", "footer"),
+	}
+	scores := Yake(sentences, DefaultYakeWindow)
+	if scores["Kubernetes"] >= scores["footer"] {
+		t.Errorf("expected Kubernetes to score lower (better) than footer: %v", scores)
+	}
+	if _, ok := scores["the"]; ok {
+		t.Error("non-candidate tokens should not be scored")
+	}
+}
+
+func TestYakeToRanks(t *testing.T) {
+	ranks := YakeToRanks(map[string]float64{"a": 0.1, "b": 0.2})
+	if ranks["a"] != 1 || ranks["b"] != 0.5 {
+		t.Errorf("unexpected ranks: %v", ranks)
+	}
+}
+
+func TestIsAcronym(t *testing.T) {
+	if !isAcronym("NASA") || !isAcronym("HTML5") {
+		t.Error("expected acronyms")
+	}
+	if isAcronym("Go") || isAcronym("A") {
+		t.Error("expected non-acronyms")
+	}
+}
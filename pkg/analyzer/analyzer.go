@@ -1,21 +1,31 @@
 package analyzer
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/xshoji/go-site-keyword/internal/fetcher"
-	"github.com/xshoji/go-site-keyword/internal/language"
+	"github.com/xshoji/go-site-keyword/internal/language/chinese"
 	"github.com/xshoji/go-site-keyword/internal/language/english"
+	"github.com/xshoji/go-site-keyword/internal/language/european"
 	"github.com/xshoji/go-site-keyword/internal/language/japanese"
+	"github.com/xshoji/go-site-keyword/internal/language/korean"
 	"github.com/xshoji/go-site-keyword/internal/parser"
 	"github.com/xshoji/go-site-keyword/internal/scoring"
 	"github.com/xshoji/go-site-keyword/pkg/config"
 	"github.com/xshoji/go-site-keyword/pkg/types"
+	"github.com/xshoji/go-site-keyword/pkg/utils"
 )
 
+// ErrNotLoaded は Load / LoadHTML の前に解析しようとした場合のエラーです
+var ErrNotLoaded = errors.New("no document loaded (call Load or LoadHTML first)")
+
 type PageData struct {
 	Title       string
 	MetaTags    map[string]string
@@ -27,27 +37,123 @@ type Analyzer struct {
 	responseBody []byte
 	doc          *parser.HTMLDocument
 	Config       config.Config
+	// contentLanguage は取得時の Content-Language ヘッダー（言語判定の手がかり）
+	contentLanguage string
+	// xRobotsTag は取得時の X-Robots-Tag ヘッダー（noindex の監査用）
+	xRobotsTag string
+	// language は判定済みのページの言語（Language で遅延評価）
+	language *types.LanguageDetection
+
+	// 差し替え可能なコンポーネント（nil の場合は組み込み実装を使用）
+	fetcher    types.PageFetcher
+	parser     types.DocumentParser
+	extractors map[string]types.KeywordExtractor
+	scorer     types.KeywordScorer
+	httpClient *http.Client
+}
+
+// New は Option を適用した Analyzer を生成します（ページの取得は Load で行います）
+func New(opts ...Option) *Analyzer {
+	a := &Analyzer{Config: config.DefaultConfig()}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 func NewAnalyzer(url string, cfg config.Config) (*Analyzer, error) {
-	res, err := fetcher.FetchURL(url, int(cfg.Timeout.Seconds()))
-	if err != nil {
+	return NewAnalyzerContext(context.Background(), url, cfg)
+}
+
+// NewAnalyzerContext は ctx のキャンセル・期限に従ってページを取得・解析します
+func NewAnalyzerContext(ctx context.Context, url string, cfg config.Config) (*Analyzer, error) {
+	a := New(WithConfig(cfg))
+	if err := a.Load(ctx, url); err != nil {
 		return nil, err
 	}
-	doc, err := parser.ParseHTMLDocument(string(res.Body))
+	return a, nil
+}
+
+// Load は url のページを取得してHTMLを解析し、Analyzer に読み込みます
+func (a *Analyzer) Load(ctx context.Context, url string) error {
+	res, err := a.fetch(ctx, url)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := a.LoadHTML(res.URL, res.Body); err != nil {
+		return err
+	}
+	a.contentLanguage = res.ContentLanguage
+	a.xRobotsTag = res.XRobotsTag
+	return nil
+}
+
+// LoadHTML は取得済みのHTMLを解析して Analyzer に読み込みます
+func (a *Analyzer) LoadHTML(url string, body []byte) error {
+	doc, err := parser.ParseHTMLDocument(string(body))
+	if err != nil {
+		return err
+	}
+	a.URL = url
+	a.responseBody = body
+	a.doc = doc
+	a.contentLanguage = ""
+	a.xRobotsTag = ""
+	a.language = nil
+	return nil
+}
+
+// fetch は差し替えられた PageFetcher、なければ組み込みの fetcher でページを取得します
+func (a *Analyzer) fetch(ctx context.Context, url string) (*fetcher.FetchResult, error) {
+	if a.fetcher == nil {
+		opts := fetchOptions(a.Config)
+		opts.Client = a.httpClient
+		return fetcher.FetchURLContext(ctx, url, opts)
+	}
+	var body []byte
+	var err error
+	if f, ok := a.fetcher.(types.ContextPageFetcher); ok {
+		body, err = f.FetchContext(ctx, url, a.Config.Timeout)
+	} else {
+		body, err = a.fetcher.Fetch(url, a.Config.Timeout)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &Analyzer{
-		URL:          res.URL,
-		responseBody: res.Body,
-		doc:          doc,
-		Config:       cfg,
-	}, nil
+	return &fetcher.FetchResult{URL: url, Body: body}, nil
+}
+
+// fetchOptions は Config から取得設定を組み立てます
+func fetchOptions(cfg config.Config) fetcher.Options {
+	return fetcher.Options{
+		TimeoutSeconds:      int(cfg.Timeout.Seconds()),
+		UserAgent:           cfg.UserAgent,
+		MaxBodySize:         cfg.MaxBodySize,
+		TruncateLargeBody:   cfg.TruncateLargeBody,
+		AllowedContentTypes: cfg.AllowedContentTypes,
+	}
+}
+
+// document は読み込み済みの文書を返します（未読み込みなら ErrNotLoaded）
+func (a *Analyzer) document() (*parser.HTMLDocument, error) {
+	if a.doc == nil {
+		return nil, ErrNotLoaded
+	}
+	return a.doc, nil
 }
 
 func (a *Analyzer) FetchTitle() (string, error) {
-	titles := a.doc.FetchTags("title")
+	doc, err := a.document()
+	if err != nil {
+		return "", err
+	}
+	if a.parser != nil {
+		return a.parser.ParseTitle(doc.Doc)
+	}
+	titles := doc.FetchTags("title")
 	if len(titles) == 0 {
 		return "", nil
 	}
@@ -55,25 +161,63 @@ func (a *Analyzer) FetchTitle() (string, error) {
 }
 
 func (a *Analyzer) FetchMetaTags() (map[string]string, error) {
-	return a.doc.FetchMetaTags(), nil
+	doc, err := a.document()
+	if err != nil {
+		return nil, err
+	}
+	if a.parser != nil {
+		return a.parser.ParseMetaTags(doc.Doc)
+	}
+	return doc.FetchMetaTags(), nil
 }
 
 func (a *Analyzer) FetchMainContent() (string, error) {
-	var content string
-	hTags := a.doc.FetchTags("h1")
-	hTags = append(hTags, a.doc.FetchTags("h2")...)
-	hTags = append(hTags, a.doc.FetchTags("h3")...)
-	for _, headingText := range hTags {
-		if headingText != "" {
-			content += headingText + " " + headingText + " " + headingText + " "
+	doc, err := a.document()
+	if err != nil {
+		return "", err
+	}
+	if a.parser != nil {
+		return a.parser.ParseMainContent(doc.Doc)
+	}
+	var texts []string
+	for _, heading := range doc.FetchHeadings() {
+		texts = append(texts, heading.Text)
+	}
+	return strings.Join(texts, " "), nil
+}
+
+// FetchHeadingOutline は h1〜h6 の見出しを入れ子の構造で返します
+// 直前の見出しよりレベルが深い見出しはその子になります（h2 の次の h4 も h2 の子）
+// 文書が読み込まれていない場合は nil を返します
+func (a *Analyzer) FetchHeadingOutline() []types.HeadingOutline {
+	doc, err := a.document()
+	if err != nil {
+		return nil
+	}
+	var roots []types.HeadingOutline
+	// stack は現在の見出しまでの祖先へのパス（roots / Children 内のインデックス）
+	var stack []int
+	levels := []int{}
+	for _, heading := range doc.FetchHeadings() {
+		for len(levels) > 0 && levels[len(levels)-1] >= heading.Level {
+			levels = levels[:len(levels)-1]
+			stack = stack[:len(stack)-1]
+		}
+		node := types.HeadingOutline{Level: heading.Level, Text: heading.Text}
+		siblings := &roots
+		for _, idx := range stack {
+			siblings = &(*siblings)[idx].Children
 		}
+		*siblings = append(*siblings, node)
+		stack = append(stack, len(*siblings)-1)
+		levels = append(levels, heading.Level)
 	}
-	return content, nil
+	return roots
 }
 
 func (a *Analyzer) CollectPageData() (*PageData, error) {
 	title, _ := a.FetchTitle()
-	meta := a.doc.FetchMetaTags()
+	meta, _ := a.FetchMetaTags()
 	content, _ := a.FetchMainContent()
 	return &PageData{
 		Title:       title,
@@ -83,41 +227,28 @@ func (a *Analyzer) CollectPageData() (*PageData, error) {
 }
 
 func (a *Analyzer) GetTopKeywords(n int, stopWords map[string]int, normalizeKeyword func(string) string) ([]scoring.KeywordWithScore, error) {
+	return a.GetTopKeywordsContext(context.Background(), n, stopWords, normalizeKeyword)
+}
+
+// GetTopKeywordsContext は GetTopKeywords の context 対応版です
+func (a *Analyzer) GetTopKeywordsContext(ctx context.Context, n int, stopWords map[string]int, normalizeKeyword func(string) string) ([]scoring.KeywordWithScore, error) {
+	doc, err := a.document()
+	if err != nil {
+		return nil, err
+	}
 	cfg := a.Config
 	weightMetaKeyword := cfg.ScoreWeights.MetaKeyword
 	weightTitle := cfg.ScoreWeights.Title
 	weightDesc := cfg.ScoreWeights.Description
-	weightMain := cfg.ScoreWeights.MainContent
 	if n <= 0 {
 		n = cfg.MaxKeywords
 	}
 	scoreMap := map[string]int{}
 	originalMap := map[string]string{}
 
-	// タイトル
+	// 出現元ごとのテキストと重み（文書内の並び順。この順序が同点時の出現元の優先度になる）
 	title, _ := a.FetchTitle()
-	if title != "" {
-		for _, k := range extractKeywords(title, stopWords, normalizeKeyword) {
-			normKey := k
-			scoreMap[normKey] += weightTitle
-			if existing, ok := originalMap[normKey]; !ok || len(k) > len(existing) {
-				originalMap[normKey] = k
-			}
-		}
-	}
-
-	// メタキーワード
-	meta := a.doc.FetchMetaTags()
-	if keywords, ok := meta["keywords"]; ok {
-		for _, k := range extractKeywords(keywords, stopWords, normalizeKeyword) {
-			normKey := k
-			scoreMap[normKey] += weightMetaKeyword
-			if existing, ok := originalMap[normKey]; !ok || len(k) > len(existing) {
-				originalMap[normKey] = k
-			}
-		}
-	}
-
+	meta, _ := a.FetchMetaTags()
 	// 説明文
 	desc := ""
 	if d, ok := meta["description"]; ok {
@@ -126,37 +257,204 @@ func (a *Analyzer) GetTopKeywords(n int, stopWords map[string]int, normalizeKeyw
 	if d, ok := meta["og:description"]; ok && len(d) > len(desc) {
 		desc = d
 	}
-	if desc != "" {
-		for _, k := range extractKeywords(desc, stopWords, normalizeKeyword) {
-			normKey := k
-			scoreMap[normKey] += weightDesc
-			if existing, ok := originalMap[normKey]; !ok || len(k) > len(existing) {
-				originalMap[normKey] = k
+	type source struct {
+		text   string
+		weight int
+	}
+	sources := []source{
+		{title, weightTitle},                  // タイトル
+		{meta["keywords"], weightMetaKeyword}, // メタキーワード
+		{desc, weightDesc},                    // 説明文
+	}
+	if a.parser != nil {
+		// メインコンテンツ（差し替えた DocumentParser の本文）
+		mainContent, _ := a.FetchMainContent()
+		sources = append(sources, source{mainContent, cfg.ScoreWeights.MainContent})
+	} else {
+		// 見出し（レベルごとの重み）
+		levelTexts := make([][]string, 7)
+		for _, heading := range doc.FetchHeadings() {
+			levelTexts[heading.Level] = append(levelTexts[heading.Level], heading.Text)
+		}
+		for level := 1; level <= 6; level++ {
+			sources = append(sources, source{strings.Join(levelTexts[level], " "), cfg.ScoreWeights.HeadingWeight(level)})
+		}
+	}
+	// 画像の alt 属性・図のキャプション
+	sources = append(sources,
+		source{strings.Join(doc.FetchImageAlts(), " "), cfg.ScoreWeights.ImageAlt},
+		source{strings.Join(doc.FetchFigcaptions(), " "), cfg.ScoreWeights.Figcaption},
+	)
+	// リンクテキスト（設定により内部リンク・外部リンクを区別）
+	var anchorTexts, internalTexts, externalTexts []string
+	for _, anchor := range doc.FetchAnchors(a.URL) {
+		anchorTexts = append(anchorTexts, anchor.Text)
+		if anchor.Internal {
+			internalTexts = append(internalTexts, anchor.Text)
+		} else {
+			externalTexts = append(externalTexts, anchor.Text)
+		}
+	}
+	if cfg.DistinguishAnchorTypes {
+		sources = append(sources,
+			source{strings.Join(internalTexts, " "), cfg.ScoreWeights.InternalAnchorText},
+			source{strings.Join(externalTexts, " "), cfg.ScoreWeights.ExternalAnchorText},
+		)
+	} else {
+		sources = append(sources, source{strings.Join(anchorTexts, " "), cfg.ScoreWeights.AnchorText})
+	}
+
+	orderMap := map[string]scoring.KeywordOrder{}
+	offset := 0
+	for priority, src := range sources {
+		if src.text != "" && src.weight != 0 {
+			keywords, err := a.extractKeywordsContext(ctx, src.text, stopWords, normalizeKeyword)
+			if err != nil {
+				return nil, err
+			}
+			lowerText := strings.ToLower(src.text)
+			for _, k := range keywords {
+				normKey := k.norm
+				scoreMap[normKey] += src.weight
+				if existing, ok := originalMap[normKey]; !ok || len(k.surface) > len(existing) {
+					originalMap[normKey] = k.surface
+				}
+				if _, ok := orderMap[normKey]; !ok {
+					orderMap[normKey] = scoring.KeywordOrder{
+						FirstPosition:  offset + runeIndex(lowerText, strings.ToLower(k.surface)),
+						SourcePriority: priority,
+					}
+				}
 			}
 		}
+		offset += utf8.RuneCountInString(src.text) + 1
 	}
 
-	// メインコンテンツ
-	mainContent, _ := a.FetchMainContent()
-	if mainContent != "" {
-		for _, k := range extractKeywords(mainContent, stopWords, normalizeKeyword) {
-			normKey := k
-			scoreMap[normKey] += weightMain
-			if existing, ok := originalMap[normKey]; !ok || len(k) > len(existing) {
-				originalMap[normKey] = k
+	if cfg.ProminenceBoost {
+		if err := a.applyProminenceBoost(ctx, scoreMap, stopWords, normalizeKeyword); err != nil {
+			return nil, err
+		}
+	}
+	return a.rank(scoreMap, originalMap, orderMap, n), nil
+}
+
+// runeIndex は text 内で substr が最初に現れる文字位置を返します（見つからなければ text の末尾）
+func runeIndex(text, substr string) int {
+	idx := strings.Index(text, substr)
+	if idx < 0 {
+		return utf8.RuneCountInString(text)
+	}
+	return utf8.RuneCountInString(text[:idx])
+}
+
+// rank はスコアマップを上位 n 件に絞り、正規化スコアを付与します（WithScorer で差し替え可）
+// 同点の場合は orderMap（文書内の初出位置・出現元の優先度）、最後に辞書順で並べます
+func (a *Analyzer) rank(scoreMap map[string]int, originalMap map[string]string, orderMap map[string]scoring.KeywordOrder, n int) []scoring.KeywordWithScore {
+	var ranked []scoring.KeywordWithScore
+	if a.scorer != nil {
+		ranked = a.scorer.Rank(scoreMap, originalMap, n)
+	} else {
+		ranked = scoring.RankKeywordsByScoreOrdered(scoreMap, originalMap, orderMap, n)
+	}
+	return scoring.Normalize(ranked, scoreMap, a.Config.Normalization)
+}
+
+// extractKeywordsContext: テキストを正規化して文字種ごとの区間に分け、区間の言語に応じた抽出結果を初出順にまとめる
+// norm は集計に使うキーワード、surface は元のテキストでの表記（表示用）
+func (a *Analyzer) extractKeywordsContext(ctx context.Context, text string, stopWords map[string]int, normalizeKeyword func(string) string) ([]keywordToken, error) {
+	normalized := a.normalizeText(text)
+	var result []keywordToken
+	seen := map[string]bool{}
+	for _, seg := range a.splitByLanguage(normalized.Text) {
+		if seg.lang == LangJapanese && a.extractors[LangJapanese] == nil && a.Config.JapaneseMergeByReading {
+			// 読みで集計する場合は表記と集計キーが異なるため、トークンの表記をそのまま使う
+			tokens, err := a.segmentTokensContext(ctx, seg)
+			if err != nil {
+				return nil, err
+			}
+			for _, tok := range tokens {
+				if !seen[tok.norm] {
+					seen[tok.norm] = true
+					result = append(result, keywordToken{surface: normalized.Original(tok.surface), norm: tok.norm})
+				}
+			}
+			continue
+		}
+		keywords, err := a.extractSegmentContext(ctx, seg, stopWords, normalizeKeyword)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keywords {
+			if !seen[k.norm] {
+				seen[k.norm] = true
+				result = append(result, keywordToken{surface: normalized.Original(k.surface), norm: k.norm})
 			}
 		}
 	}
+	return result, nil
+}
 
-	return scoring.RankKeywordsByScore(scoreMap, originalMap, n), nil
+// extractSegmentContext: 区間の言語に応じて適切な抽出関数を呼ぶ（WithExtractor で差し替え可）
+// ドイツ語・フランス語などは語幹を集計キーにし、出現元をまたいで活用形をまとめる
+func (a *Analyzer) extractSegmentContext(ctx context.Context, seg languageSegment, stopWords map[string]int, normalizeKeyword func(string) string) ([]keywordToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if e, ok := a.extractors[seg.lang]; ok {
+		extracted, err := e.Extract(seg.text)
+		if err != nil {
+			return nil, err
+		}
+		keywords := make([]string, 0, len(extracted))
+		for _, kw := range extracted {
+			keywords = append(keywords, kw.Keyword)
+		}
+		return sameKeyTokens(keywords), nil
+	}
+	var keywords []string
+	var err error
+	switch seg.lang {
+	case LangJapanese:
+		t, terr := a.japaneseTokenizer()
+		if terr != nil {
+			return nil, terr
+		}
+		keywords, err = t.ExtractKeywordsContext(ctx, seg.text)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]keywordToken, 0, len(keywords))
+		for _, k := range keywords {
+			result = append(result, keywordToken{surface: k, norm: a.foldKey(k)})
+		}
+		return result, nil
+	case LangChinese:
+		keywords, err = chinese.ExtractChineseKeywordsContext(ctx, seg.text)
+	case LangKorean:
+		keywords, err = korean.ExtractKoreanKeywordsContext(ctx, seg.text)
+	default:
+		if european.IsSupported(seg.lang) {
+			var result []keywordToken
+			for _, kw := range european.ExtractKeywords(seg.text, seg.lang, a.Config.EuropeanStopWords[seg.lang]) {
+				result = append(result, keywordToken{surface: kw.Surface, norm: kw.Stem})
+			}
+			return result, nil
+		}
+		keywords = english.ExtractEnglishKeywords(seg.text, stopWords, normalizeKeyword)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sameKeyTokens(keywords), nil
 }
 
-// extractKeywords: 言語自動判定して適切な抽出関数を呼ぶ
-func extractKeywords(text string, stopWords map[string]int, normalizeKeyword func(string) string) []string {
-	if language.ContainsJapanese(text) {
-		return japanese.ExtractJapaneseKeywords(text)
+// sameKeyTokens は表記をそのまま集計キーにしたキーワード候補を返します
+func sameKeyTokens(keywords []string) []keywordToken {
+	result := make([]keywordToken, 0, len(keywords))
+	for _, k := range keywords {
+		result = append(result, keywordToken{surface: k, norm: k})
 	}
-	return english.ExtractEnglishKeywords(text, stopWords, normalizeKeyword)
+	return result
 }
 
 // ページ取得の分離
@@ -171,19 +469,32 @@ func ParseDocument(body []byte) (*goquery.Document, error) {
 }
 
 // キーワード抽出の分離
+// ページの解析と同じく文字種ごとの区間に分け、区間の言語（日本語・中国語・韓国語・英語）の抽出器でキーワードを抽出します
+// isJapanese が true なら漢字のみの区間も日本語として扱い、false ならテキストから判定します
 func ExtractKeywords(content string, isJapanese bool, stopWords map[string]int, normalizeKeyword func(string) string) ([]string, error) {
-	if isJapanese || language.ContainsJapanese(content) {
-		return japanese.ExtractJapaneseKeywords(content), nil
+	cfg := config.DefaultConfig()
+	if isJapanese {
+		cfg.Language = LangJapanese
+	}
+	a := &Analyzer{Config: cfg}
+	tokens, err := a.extractKeywordsContext(context.Background(), content, stopWords, normalizeKeyword)
+	if err != nil {
+		return nil, err
 	}
-	return english.ExtractEnglishKeywords(content, stopWords, normalizeKeyword), nil
+	result := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		result = append(result, tok.surface)
+	}
+	return result, nil
 }
 
-// ExtractKeywordsWithFrequency テキストからキーワードとその頻度を抽出します
+// ExtractKeywordsWithFrequency テキストからキーワードとその頻度を抽出します（頻度順、同頻度は初出順）
 func ExtractKeywordsWithFrequency(text string, stopWords map[string]int, normalizeKeyword func(string) string) []scoring.KeywordWithScore {
 	words := strings.Fields(strings.ToLower(text))
 	wordFreq := map[string]int{}
 	normalizedWords := map[string][]string{}
 	normalizedScores := map[string]int{}
+	var order []string // 正規化キーの初出順
 	for _, w := range words {
 		if _, skip := stopWords[w]; skip || len(w) <= 1 || w == "-" {
 			continue
@@ -194,11 +505,15 @@ func ExtractKeywordsWithFrequency(text string, stopWords map[string]int, normali
 			if norm != w {
 				normalizedWords[norm] = append(normalizedWords[norm], w)
 			}
+			if _, seen := normalizedScores[norm]; !seen {
+				order = append(order, norm)
+			}
 			normalizedScores[norm]++
 		}
 	}
 	var result []scoring.KeywordWithScore
-	for norm, score := range normalizedScores {
+	for _, norm := range order {
+		score := normalizedScores[norm]
 		bestWord := norm
 		bestScore := 0
 		if originals, exists := normalizedWords[norm]; exists && len(originals) > 0 {
@@ -214,6 +529,10 @@ func ExtractKeywordsWithFrequency(text string, stopWords map[string]int, normali
 			Score:   score,
 		})
 	}
+	// 頻度順、同頻度は初出順（安定ソート）
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
 	return result
 }
 
@@ -230,20 +549,339 @@ func (a *Analyzer) GetTopKeywordsWithDefaultConfig(n int) ([]scoring.KeywordWith
 	return ExtractKeywordsWithFrequency(mainContent, stopWords, normalize), nil
 }
 
+// normalizeFunc は Config の単複変換マップ・不変語を使う英単語の正規化関数を返します
+func (a *Analyzer) normalizeFunc() func(string) string {
+	pluralSingularMap := a.Config.PluralSingularMap
+	invariantWords := a.Config.InvariantWords
+	return func(word string) string {
+		return english.NormalizeEnglishKeyword(word, pluralSingularMap, invariantWords)
+	}
+}
+
 // stopWords, normalizeKeyword をConfigから自動で利用するバージョン
 func (a *Analyzer) GetTopKeywordsAuto(n int) ([]scoring.KeywordWithScore, error) {
+	return a.GetTopKeywordsAutoContext(context.Background(), n)
+}
+
+// GetTopKeywordsAutoContext は GetTopKeywordsAuto の context 対応版です
+func (a *Analyzer) GetTopKeywordsAutoContext(ctx context.Context, n int) ([]scoring.KeywordWithScore, error) {
 	cfg := a.Config
 	stopWords := cfg.EnglishStopWords
-	pluralSingularMap := cfg.PluralSingularMap
-	invariantWords := cfg.InvariantWords
-	normalize := func(word string) string {
-		return english.NormalizeEnglishKeyword(word, pluralSingularMap, invariantWords)
+	normalize := a.normalizeFunc()
+	switch cfg.Algorithm {
+	case scoring.AlgorithmTextRank:
+		return a.GetTopKeywordsTextRankContext(ctx, n, stopWords, normalize)
+	case scoring.AlgorithmYake:
+		return a.GetTopKeywordsYakeContext(ctx, n, stopWords, normalize)
 	}
-	return a.GetTopKeywords(n, stopWords, normalize)
+	return a.GetTopKeywordsContext(ctx, n, stopWords, normalize)
+}
+
+// GetTopKeywordsYakeContext はページ本文を文に分割し YAKE の特徴量でキーワードを返します
+// スコアは最も重要な語を 100 とした相対値です
+func (a *Analyzer) GetTopKeywordsYakeContext(ctx context.Context, n int, stopWords map[string]int, normalizeKeyword func(string) string) ([]scoring.KeywordWithScore, error) {
+	cfg := a.Config
+	if n <= 0 {
+		n = cfg.MaxKeywords
+	}
+	originalMap := map[string]string{}
+	orderMap := map[string]scoring.KeywordOrder{}
+	position := 0
+	var sentences [][]scoring.YakeToken
+	textSegments, err := a.textSegments()
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range textSegments {
+		for _, sentence := range utils.SplitSentences(segment.text) {
+			normalized := a.normalizeText(sentence)
+			tokens, err := a.yakeTokensContext(ctx, normalized.Text, stopWords, normalizeKeyword)
+			if err != nil {
+				return nil, err
+			}
+			if len(tokens) == 0 {
+				continue
+			}
+			for _, tok := range tokens {
+				position++
+				if !tok.Candidate {
+					continue
+				}
+				surface := strings.ToLower(normalized.Original(tok.Surface))
+				if existing, ok := originalMap[tok.Key]; !ok || len(surface) > len(existing) {
+					originalMap[tok.Key] = surface
+				}
+				if _, ok := orderMap[tok.Key]; !ok {
+					orderMap[tok.Key] = scoring.KeywordOrder{FirstPosition: position, SourcePriority: segment.priority}
+				}
+			}
+			sentences = append(sentences, tokens)
+		}
+	}
+	window := cfg.YakeWindow
+	if window <= 0 {
+		window = scoring.DefaultYakeWindow
+	}
+	scoreMap := scoring.RanksToScores(scoring.YakeToRanks(scoring.Yake(sentences, window)))
+	return a.rank(scoreMap, originalMap, orderMap, n), nil
+}
+
+// yakeTokensContext: 正規化済みの1文をYAKE用のトークン列にする（英語・ヨーロッパ言語はストップワードも文脈として残す）
+func (a *Analyzer) yakeTokensContext(ctx context.Context, sentence string, stopWords map[string]int, normalizeKeyword func(string) string) ([]scoring.YakeToken, error) {
+	var result []scoring.YakeToken
+	for _, seg := range a.splitByLanguage(sentence) {
+		if usesSegmenter(seg.lang) {
+			tokens, err := a.segmentTokensContext(ctx, seg)
+			if err != nil {
+				return nil, err
+			}
+			for _, tok := range tokens {
+				result = append(result, scoring.YakeToken{Key: tok.norm, Surface: tok.surface, Candidate: true})
+			}
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if european.IsSupported(seg.lang) {
+			europeanStopWords := a.Config.EuropeanStopWords[seg.lang]
+			for _, w := range european.SplitWords(seg.text) {
+				lower := strings.ToLower(w)
+				result = append(result, scoring.YakeToken{Key: european.Stem(lower, seg.lang), Surface: w, Candidate: european.IsKeywordCandidate(lower, europeanStopWords)})
+			}
+			continue
+		}
+		for _, w := range english.SplitEnglishWords(seg.text) {
+			lower := strings.ToLower(w)
+			candidate := english.IsKeywordCandidate(lower, stopWords, normalizeKeyword)
+			key := lower
+			if candidate {
+				key = normalizeKeyword(lower)
+			}
+			result = append(result, scoring.YakeToken{Key: key, Surface: w, Candidate: candidate})
+		}
+	}
+	return result, nil
+}
+
+// GetTopKeywordsTextRankContext はページ本文の共起グラフに TextRank を適用してキーワードを返します
+// タイトル・説明文・見出し・段落をそれぞれ区切りとして扱います
+func (a *Analyzer) GetTopKeywordsTextRankContext(ctx context.Context, n int, stopWords map[string]int, normalizeKeyword func(string) string) ([]scoring.KeywordWithScore, error) {
+	cfg := a.Config
+	if n <= 0 {
+		n = cfg.MaxKeywords
+	}
+	originalMap := map[string]string{}
+	orderMap := map[string]scoring.KeywordOrder{}
+	position := 0
+	var segments [][]string
+	textSegments, err := a.textSegments()
+	if err != nil {
+		return nil, err
+	}
+	for _, seg := range textSegments {
+		tokens, err := a.tokenizeKeywordsContext(ctx, seg.text, stopWords, normalizeKeyword)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		segment := make([]string, 0, len(tokens))
+		for _, tok := range tokens {
+			position++
+			segment = append(segment, tok.norm)
+			if existing, ok := originalMap[tok.norm]; !ok || len(tok.surface) > len(existing) {
+				originalMap[tok.norm] = tok.surface
+			}
+			if _, ok := orderMap[tok.norm]; !ok {
+				orderMap[tok.norm] = scoring.KeywordOrder{FirstPosition: position, SourcePriority: seg.priority}
+			}
+		}
+		segments = append(segments, segment)
+	}
+	window := cfg.TextRankWindow
+	if window <= 0 {
+		window = scoring.DefaultTextRankWindow
+	}
+	scoreMap := scoring.RanksToScores(scoring.TextRank(segments, window, scoring.DefaultTextRankDamping))
+	return a.rank(scoreMap, originalMap, orderMap, n), nil
+}
+
+// textSegment は TextRank / YAKE の入力となるテキストの区切り
+type textSegment struct {
+	text string
+	// priority は出現元の優先度（タイトル 0, 説明文 1, 見出し 2, 段落 3）
+	priority int
+}
+
+// textSegments は TextRank / YAKE の入力となるページ内テキストを文書順に区切りごとに返します
+// DocumentParser を差し替えた場合は見出し・段落の代わりにその本文を使います
+func (a *Analyzer) textSegments() ([]textSegment, error) {
+	doc, err := a.document()
+	if err != nil {
+		return nil, err
+	}
+	var segments []textSegment
+	if title, _ := a.FetchTitle(); title != "" {
+		segments = append(segments, textSegment{title, 0})
+	}
+	meta, _ := a.FetchMetaTags()
+	if desc, ok := meta["description"]; ok && desc != "" {
+		segments = append(segments, textSegment{desc, 1})
+	} else if desc, ok := meta["og:description"]; ok && desc != "" {
+		segments = append(segments, textSegment{desc, 1})
+	}
+	if a.parser != nil {
+		if content, _ := a.FetchMainContent(); strings.TrimSpace(content) != "" {
+			segments = append(segments, textSegment{content, 3})
+		}
+		return segments, nil
+	}
+	doc.Doc.Find("h1, h2, h3, h4, h5, h6, p, li").Each(func(i int, sel *goquery.Selection) {
+		text := sel.Text()
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		priority := 3
+		if goquery.NodeName(sel)[0] == 'h' {
+			priority = 2
+		}
+		segments = append(segments, textSegment{text, priority})
+	})
+	return segments, nil
+}
+
+// keywordToken はキーワード候補の表記と正規化キーの組
+type keywordToken struct {
+	surface string
+	norm    string
+}
+
+// tokenizeKeywordsContext: テキストを正規化し、文字種ごとの区間の言語に応じてキーワード候補を出現順に返す
+// surface は元のテキストでの表記です
+func (a *Analyzer) tokenizeKeywordsContext(ctx context.Context, text string, stopWords map[string]int, normalizeKeyword func(string) string) ([]keywordToken, error) {
+	normalized := a.normalizeText(text)
+	result, err := a.tokenizeNormalizedContext(ctx, normalized.Text, stopWords, normalizeKeyword)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].surface = normalized.Original(result[i].surface)
+	}
+	return result, nil
+}
+
+// tokenizeNormalizedContext: 正規化済みのテキストを文字種ごとの区間の言語に応じてキーワード候補に分割する
+func (a *Analyzer) tokenizeNormalizedContext(ctx context.Context, text string, stopWords map[string]int, normalizeKeyword func(string) string) ([]keywordToken, error) {
+	var result []keywordToken
+	for _, seg := range a.splitByLanguage(text) {
+		if usesSegmenter(seg.lang) {
+			tokens, err := a.segmentTokensContext(ctx, seg)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, tokens...)
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if european.IsSupported(seg.lang) {
+			for _, s := range european.TokenizeKeywords(seg.text, a.Config.EuropeanStopWords[seg.lang]) {
+				result = append(result, keywordToken{surface: s, norm: european.Stem(s, seg.lang)})
+			}
+			continue
+		}
+		for _, s := range english.TokenizeEnglishKeywords(seg.text, stopWords, normalizeKeyword) {
+			result = append(result, keywordToken{surface: s, norm: normalizeKeyword(s)})
+		}
+	}
+	return result, nil
+}
+
+// usesSegmenter は空白区切りではなく言語ごとの解析（形態素解析・分かち書き・助詞の除去）で候補を求める言語か判定します
+func usesSegmenter(lang string) bool {
+	return lang == LangJapanese || lang == LangChinese || lang == LangKorean
+}
+
+// segmentTokensContext: 日本語・中国語・韓国語の区間を解析してキーワード候補を出現順に返す
+func (a *Analyzer) segmentTokensContext(ctx context.Context, seg languageSegment) ([]keywordToken, error) {
+	var result []keywordToken
+	if seg.lang == LangKorean {
+		nouns, err := korean.TokenizeKoreanKeywordsContext(ctx, seg.text)
+		if err != nil {
+			return nil, err
+		}
+		for _, noun := range nouns {
+			result = append(result, keywordToken{surface: noun, norm: noun})
+		}
+		return result, nil
+	}
+	if seg.lang == LangChinese {
+		tokens, err := chinese.TokenizeChineseKeywordsContext(ctx, seg.text)
+		if err != nil {
+			return nil, err
+		}
+		for _, tok := range tokens {
+			result = append(result, keywordToken{surface: tok.Surface, norm: tok.Key})
+		}
+		return result, nil
+	}
+	t, err := a.japaneseTokenizer()
+	if err != nil {
+		return nil, err
+	}
+	surfaces, err := t.TokenizeKeywordsContext(ctx, seg.text)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range surfaces {
+		norm := a.foldKey(strings.ToLower(s))
+		if a.Config.JapaneseMergeByReading {
+			norm = t.MergeKey(s)
+		}
+		result = append(result, keywordToken{surface: s, norm: norm})
+	}
+	return result, nil
+}
+
+// japaneseTokenizer は Config の辞書・ユーザー辞書を読み込んだ日本語の Tokenizer を返します
+// 品詞・文字数の条件は Config.JapaneseFilter を使います
+func (a *Analyzer) japaneseTokenizer() (*japanese.Tokenizer, error) {
+	t, err := japanese.NewTokenizer(japanese.Options{
+		Dictionary:     a.Config.JapaneseDictionary,
+		DictionaryFile: a.Config.JapaneseDictionaryFile,
+		UserDictFile:   a.Config.JapaneseUserDictFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+	f := a.Config.JapaneseFilter
+	return t.WithFilter(japanese.Filter{
+		Allow:          japanese.ParsePOSRules(f.Allow),
+		Deny:           japanese.ParsePOSRules(f.Deny),
+		BaseForm:       f.BaseForm,
+		MinLength:      f.MinLength,
+		KanjiMinLength: f.KanjiMinLength,
+		StopWords:      a.Config.JapaneseStopWords,
+	}), nil
 }
 
 // GetAnalysisResult はウェブページの解析結果を返します
 func (a *Analyzer) GetAnalysisResult(maxKeywords int) (*types.AnalysisResult, error) {
+	return a.GetAnalysisResultContext(context.Background(), maxKeywords)
+}
+
+// GetAnalysisResultContext は GetAnalysisResult の context 対応版です
+// キャンセル・期限切れの場合は部分的な結果を返さず ctx.Err() を返します
+func (a *Analyzer) GetAnalysisResultContext(ctx context.Context, maxKeywords int) (*types.AnalysisResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := a.document(); err != nil {
+		return nil, err
+	}
 	result := &types.AnalysisResult{}
 	var lastErr error
 
@@ -263,12 +901,38 @@ func (a *Analyzer) GetAnalysisResult(maxKeywords int) (*types.AnalysisResult, er
 		result.MetaTags = meta
 	}
 
+	// ページの言語
+	lang := a.Language()
+	result.Language = &lang
+
+	// 見出し構造を取得
+	result.Outline = a.FetchHeadingOutline()
+
 	// キーワードを取得
-	keywordsWithScores, err := a.GetTopKeywordsAuto(maxKeywords)
+	keywordsWithScores, err := a.GetTopKeywordsAutoContext(ctx, maxKeywords)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	var prominence map[string]*types.KeywordProminence
 	if err != nil {
 		lastErr = err
 	} else if len(keywordsWithScores) > 0 {
 		result.Keywords = convertToTypeKeywords(keywordsWithScores)
+		// 出現位置・出現元
+		prominence, err = a.attachProminence(ctx, result.Keywords, a.Config.EnglishStopWords, a.normalizeFunc())
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			lastErr = err
+		}
+	}
+
+	// オンページSEO監査（キーワードがない場合もタイトル・見出しなどは監査する）
+	if a.auditEnabled() {
+		if result.Findings, err = a.audit(ctx, result.Keywords, prominence); err != nil {
+			lastErr = err
+		}
 	}
 
 	// 何かしらのデータが取得できていれば結果を返す
@@ -289,8 +953,11 @@ func convertToTypeKeywords(input []scoring.KeywordWithScore) []types.KeywordWith
 	var result []types.KeywordWithScore
 	for _, kws := range input {
 		result = append(result, types.KeywordWithScore{
-			Keyword: kws.Keyword,
-			Score:   kws.Score,
+			Keyword:         kws.Keyword,
+			Score:           kws.Score,
+			NormalizedScore: kws.NormalizedScore,
+			Percentage:      kws.Percentage,
+			Prominence:      kws.Prominence,
 		})
 	}
 	return result
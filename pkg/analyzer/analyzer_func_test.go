@@ -65,3 +65,20 @@ func TestExtractKeywords_Japanese(t *testing.T) {
 		t.Error("expected some keywords, got none")
 	}
 }
+
+func TestExtractKeywords_ScriptRuns(t *testing.T) {
+	// 漢字のみの中国語は中国語の分かち書き、ハングルは韓国語の抽出器に回す
+	keywords, err := ExtractKeywords("我们提供云计算服务。서울의 클라우드 Kubernetes", false, map[string]int{}, func(s string) string { return s })
+	if err != nil {
+		t.Fatalf("ExtractKeywords error: %v", err)
+	}
+	found := map[string]bool{}
+	for _, k := range keywords {
+		found[k] = true
+	}
+	for _, want := range []string{"云计算", "服务", "서울", "클라우드", "kubernetes"} {
+		if !found[want] {
+			t.Errorf("expected %q in keywords, got %v", want, keywords)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// --- テスト用の差し替え実装 ---
+type stubFetcher struct{ body string }
+
+func (s stubFetcher) Fetch(url string, timeout time.Duration) ([]byte, error) {
+	return []byte(s.body), nil
+}
+
+type stubParser struct{}
+
+func (stubParser) ParseTitle(doc *goquery.Document) (string, error) { return "stub title", nil }
+func (stubParser) ParseMetaTags(doc *goquery.Document) (map[string]string, error) {
+	return map[string]string{"keywords": "stub"}, nil
+}
+func (stubParser) ParseMainContent(doc *goquery.Document) (string, error) { return "", nil }
+
+type stubExtractor struct{}
+
+func (stubExtractor) Extract(text string) ([]types.KeywordWithScore, error) {
+	return []types.KeywordWithScore{{Keyword: "injected", Score: 1}}, nil
+}
+
+type stubScorer struct{}
+
+func (stubScorer) Rank(scores map[string]int, originals map[string]string, limit int) []types.KeywordWithScore {
+	return []types.KeywordWithScore{{Keyword: "scored", Score: 99}}
+}
+
+func TestNew_WithFetcher(t *testing.T) {
+	html := `<html><head><title>Stub Page</title></head><body><h1>Golang</h1></body></html>`
+	a := New(WithFetcher(stubFetcher{body: html}))
+	if err := a.Load(context.Background(), "http://example.invalid/"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	title, _ := a.FetchTitle()
+	if title != "Stub Page" {
+		t.Errorf("expected 'Stub Page', got '%s'", title)
+	}
+	if a.URL != "http://example.invalid/" {
+		t.Errorf("unexpected URL: %s", a.URL)
+	}
+}
+
+func TestNew_WithParserAndExtractor(t *testing.T) {
+	a := New(
+		WithFetcher(stubFetcher{body: "<html></html>"}),
+		WithParser(stubParser{}),
+		WithExtractor(LangEnglish, stubExtractor{}),
+	)
+	if err := a.Load(context.Background(), "http://example.invalid/"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := a.GetAnalysisResult(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Title != "stub title" {
+		t.Errorf("expected 'stub title', got '%s'", result.Title)
+	}
+	if len(result.Keywords) != 1 || result.Keywords[0].Keyword != "injected" {
+		t.Errorf("expected injected keyword, got %+v", result.Keywords)
+	}
+}
+
+func TestNew_WithScorer(t *testing.T) {
+	a := New(WithFetcher(stubFetcher{body: "<html><head><title>Go</title></head></html>"}), WithScorer(stubScorer{}))
+	if err := a.Load(context.Background(), "http://example.invalid/"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keywords, err := a.GetTopKeywordsAuto(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keywords) != 1 || keywords[0].Keyword != "scored" {
+		t.Errorf("expected scorer output, got %+v", keywords)
+	}
+}
+
+func TestNew_WithHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head><title>Client</title></head></html>"))
+	}))
+	defer ts.Close()
+
+	a := New(WithHTTPClient(ts.Client()))
+	if err := a.Load(context.Background(), ts.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	title, _ := a.FetchTitle()
+	if title != "Client" {
+		t.Errorf("expected 'Client', got '%s'", title)
+	}
+}
+
+// contentParser は本文だけを差し替える DocumentParser
+type contentParser struct{ content string }
+
+func (contentParser) ParseTitle(doc *goquery.Document) (string, error) { return "", nil }
+func (contentParser) ParseMetaTags(doc *goquery.Document) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+func (p contentParser) ParseMainContent(doc *goquery.Document) (string, error) { return p.content, nil }
+
+func TestNew_WithParserUsedForAllAlgorithms(t *testing.T) {
+	html := `<html><body><h1>Ignored heading</h1><p>Ignored paragraph</p></body></html>`
+	cfg := config.DefaultConfig()
+	for _, algorithm := range []string{"frequency", "textrank", "yake"} {
+		cfg.Algorithm = algorithm
+		a := New(WithConfig(cfg), WithParser(contentParser{content: "Kubernetes clusters on bare metal servers"}))
+		if err := a.LoadHTML("http://example.invalid/", []byte(html)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := a.GetAnalysisResult(10)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", algorithm, err)
+		}
+		for _, k := range result.Keywords {
+			if strings.Contains(strings.ToLower(k.Keyword), "ignored") {
+				t.Errorf("%s: expected document text to be bypassed, got %+v", algorithm, result.Keywords)
+			}
+		}
+		if algorithm == "frequency" {
+			continue
+		}
+		var kubernetes *types.KeywordWithScore
+		for i := range result.Keywords {
+			if strings.EqualFold(result.Keywords[i].Keyword, "Kubernetes") {
+				kubernetes = &result.Keywords[i]
+			}
+		}
+		if kubernetes == nil || kubernetes.Prominence == nil || kubernetes.Prominence.Sources[0] != "body" {
+			t.Errorf("%s: expected Kubernetes from the parser's main content, got %+v", algorithm, result.Keywords)
+		}
+	}
+}
+
+func TestNew_NotLoaded(t *testing.T) {
+	a := New()
+	if _, err := a.FetchTitle(); !errors.Is(err, ErrNotLoaded) {
+		t.Errorf("expected ErrNotLoaded from FetchTitle, got %v", err)
+	}
+	if _, err := a.GetTopKeywordsAuto(5); !errors.Is(err, ErrNotLoaded) {
+		t.Errorf("expected ErrNotLoaded from GetTopKeywordsAuto, got %v", err)
+	}
+	if _, err := a.KeywordProminenceContext(context.Background(), nil, strings.ToLower); !errors.Is(err, ErrNotLoaded) {
+		t.Errorf("expected ErrNotLoaded from KeywordProminenceContext, got %v", err)
+	}
+	if _, err := a.AuditContext(context.Background(), nil); !errors.Is(err, ErrNotLoaded) {
+		t.Errorf("expected ErrNotLoaded from AuditContext, got %v", err)
+	}
+	if _, err := a.GetAnalysisResult(5); !errors.Is(err, ErrNotLoaded) {
+		t.Errorf("expected ErrNotLoaded from GetAnalysisResult, got %v", err)
+	}
+	if a.FetchHeadingOutline() != nil || a.Language().Code != "und" {
+		t.Errorf("expected empty outline and undetermined language")
+	}
+	for _, algorithm := range []string{"textrank", "yake"} {
+		a.Config.Algorithm = algorithm
+		if _, err := a.GetTopKeywordsAuto(5); !errors.Is(err, ErrNotLoaded) {
+			t.Errorf("%s: expected ErrNotLoaded, got %v", algorithm, err)
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package analyzer
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/xshoji/go-site-keyword/internal/parser"
 	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
 )
 
 type dummyNormalizer struct{}
@@ -70,3 +77,718 @@ func TestAnalyzer_GetTopKeywords_Japanese(t *testing.T) {
 		t.Error("expected keywords, got none")
 	}
 }
+
+func TestAnalyzer_GetAnalysisResultContext_Canceled(t *testing.T) {
+	html := `<html><head><title>Go Test</title></head><body><h1>Go Test</h1></body></html>`
+	doc := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := doc.GetAnalysisResultContext(ctx, 3)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if result != nil {
+		t.Errorf("expected nil result, got %+v", result)
+	}
+}
+
+func TestAnalyzer_GetTopKeywordsAuto_TextRank(t *testing.T) {
+	html := `<html><head><title>Kubernetes cluster guide</title></head><body>
+	<p>Deploy applications to a Kubernetes cluster.</p>
+	<p>Monitoring Kubernetes workloads with Prometheus.</p>
+	<p>Kubernetes クラスタの構築と運用</p>
+	</body></html>`
+	cfg := config.DefaultConfig()
+	cfg.Algorithm = "textrank"
+	doc := NewAnalyzerFromHTML(html, cfg)
+	keywords, err := doc.GetTopKeywordsAuto(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keywords) == 0 || keywords[0].Keyword != "kubernetes" {
+		t.Errorf("expected 'kubernetes' first, got %+v", keywords)
+	}
+}
+
+func TestAnalyzer_GetTopKeywordsAuto_Yake(t *testing.T) {
+	html := `<html><head><title>Kubernetes Cluster Guide</title></head><body>
+	<p>Deploy applications to a Kubernetes cluster. Kubernetes makes scaling easy.</p>
+	<p>Contact us for a quote.</p>
+	</body></html>`
+	cfg := config.DefaultConfig()
+	cfg.Algorithm = "yake"
+	doc := NewAnalyzerFromHTML(html, cfg)
+	keywords, err := doc.GetTopKeywordsAuto(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keywords) == 0 || keywords[0].Score != 100 {
+		t.Fatalf("expected top keyword to score 100, got %+v", keywords)
+	}
+	found := false
+	for _, k := range keywords {
+		if k.Keyword == "kubernetes" {
+			found = true
+		}
+		if k.Keyword == "quote" || k.Keyword == "contact" {
+			t.Errorf("unexpected footer keyword '%s' in top 3: %+v", k.Keyword, keywords)
+		}
+	}
+	if !found {
+		t.Errorf("expected 'kubernetes' in top 3, got %+v", keywords)
+	}
+}
+
+func TestAnalyzer_GetAnalysisResult_Deterministic(t *testing.T) {
+	pages := map[string]string{
+		"english":  `<html><head><title>alpha bravo charlie</title><meta name="description" content="delta echo foxtrot"></head><body><h1>golf hotel india</h1><h2>juliet kilo lima</h2></body></html>`,
+		"japanese": `<html><head><title>東京 大阪 京都</title></head><body><h1>名古屋 福岡 札幌</h1><h2>仙台 広島 神戸</h2></body></html>`,
+	}
+	for name, html := range pages {
+		for _, algorithm := range []string{"frequency", "textrank", "yake"} {
+			cfg := config.DefaultConfig()
+			cfg.Algorithm = algorithm
+			var first string
+			for run := 0; run < 10; run++ {
+				result, err := NewAnalyzerFromHTML(html, cfg).GetAnalysisResult(5)
+				if err != nil {
+					t.Fatalf("%s/%s: unexpected error: %v", name, algorithm, err)
+				}
+				out, _ := json.Marshal(result.Keywords)
+				if run == 0 {
+					first = string(out)
+				} else if string(out) != first {
+					t.Fatalf("%s/%s run %d: output changed\nfirst: %s\ngot:   %s", name, algorithm, run, first, out)
+				}
+			}
+		}
+	}
+}
+
+func TestExtractKeywordsWithFrequency_Deterministic(t *testing.T) {
+	text := "zebra apple mango apple kiwi mango banana"
+	expected := []string{"apple", "mango", "zebra", "kiwi", "banana"}
+	for run := 0; run < 20; run++ {
+		result := ExtractKeywordsWithFrequency(text, map[string]int{}, func(s string) string { return s })
+		for i := range expected {
+			if result[i].Keyword != expected[i] {
+				t.Fatalf("run %d: expected %v, got %+v", run, expected, result)
+			}
+		}
+	}
+}
+
+func TestAnalyzer_GetAnalysisResult_Prominence(t *testing.T) {
+	html := `<html><head><title>Golang tips</title></head><body><h1>Golang</h1><p>Learn golang today. <a href="/">Golang docs</a></p></body></html>`
+	result, err := NewAnalyzerFromHTML(html, config.DefaultConfig()).GetAnalysisResult(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var p *types.KeywordProminence
+	for _, k := range result.Keywords {
+		if k.Keyword == "golang" {
+			p = k.Prominence
+		}
+	}
+	if p == nil {
+		t.Fatalf("expected prominence for 'golang', got %+v", result.Keywords)
+	}
+	if p.FirstOffset != 0 || p.DOMPath != "html > head > title" {
+		t.Errorf("unexpected first occurrence: %+v", p)
+	}
+	if p.Counts["title"] != 1 || p.Counts["h1"] != 1 || p.Counts["body"] != 1 || p.Counts["anchor"] != 1 {
+		t.Errorf("unexpected counts: %+v", p.Counts)
+	}
+	if strings.Join(p.Sources, ",") != "title,h1,body,anchor" {
+		t.Errorf("unexpected sources: %v", p.Sources)
+	}
+}
+
+func TestAnalyzer_GetTopKeywords_ProminenceBoost(t *testing.T) {
+	html := `<html><head><title>alpha</title></head><body><h1>bravo</h1></body></html>`
+	cfg := config.DefaultConfig()
+	cfg.ScoreWeights.Title = 1
+	cfg.ProminenceBoost = true
+	keywords, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// bravo: h1 4 + h1加算 3 + 冒頭 2, alpha: タイトル1 + 冒頭 2
+	if len(keywords) != 2 || keywords[0].Keyword != "bravo" || keywords[0].Score != 9 || keywords[1].Score != 3 {
+		t.Errorf("unexpected boosted keywords: %+v", keywords)
+	}
+}
+
+func TestAnalyzer_GetTopKeywords_HeadingWeights(t *testing.T) {
+	html := `<html><body><h3>charlie</h3><h2>bravo</h2><h1>alpha</h1><h6>delta</h6></body></html>`
+	keywords, err := NewAnalyzerFromHTML(html, config.DefaultConfig()).GetTopKeywordsAuto(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []struct {
+		keyword string
+		score   int
+	}{{"alpha", 4}, {"bravo", 3}, {"charlie", 2}, {"delta", 1}}
+	if len(keywords) != len(expected) {
+		t.Fatalf("expected %d keywords, got %+v", len(expected), keywords)
+	}
+	for i, e := range expected {
+		if keywords[i].Keyword != e.keyword || keywords[i].Score != e.score {
+			t.Errorf("expected %s(%d), got %+v", e.keyword, e.score, keywords[i])
+		}
+	}
+}
+
+func TestAnalyzer_FetchHeadingOutline(t *testing.T) {
+	html := `<html><body><h1>Top</h1><h2>First</h2><h4>Deep</h4><h2>Second</h2><h3>Child</h3><h1>Next</h1></body></html>`
+	outline := NewAnalyzerFromHTML(html, config.DefaultConfig()).FetchHeadingOutline()
+	if len(outline) != 2 || outline[0].Text != "Top" || outline[1].Text != "Next" {
+		t.Fatalf("unexpected roots: %+v", outline)
+	}
+	children := outline[0].Children
+	if len(children) != 2 || children[0].Text != "First" || children[1].Text != "Second" {
+		t.Fatalf("unexpected h2 children: %+v", children)
+	}
+	if len(children[0].Children) != 1 || children[0].Children[0].Level != 4 {
+		t.Errorf("expected h4 nested under First, got %+v", children[0].Children)
+	}
+	if len(children[1].Children) != 1 || children[1].Children[0].Text != "Child" {
+		t.Errorf("expected h3 nested under Second, got %+v", children[1].Children)
+	}
+}
+
+func TestAnalyzer_GetTopKeywords_AltCaptionAnchor(t *testing.T) {
+	html := `<html><body>
+	<figure><img src="a.jpg" alt="sneaker"><figcaption>boots</figcaption></figure>
+	<a href="/sandals">sandals</a><a href="https://partner.example.org/">partner</a>
+	</body></html>`
+	cfg := config.DefaultConfig()
+	a := NewAnalyzerFromHTML(html, cfg)
+	a.URL = "https://shop.example.com/"
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range keywords {
+		scores[k.Keyword] = k.Score
+	}
+	if scores["sneaker"] != 2 || scores["boots"] != 2 || scores["sandals"] != 1 || scores["partner"] != 1 {
+		t.Errorf("unexpected scores: %v", scores)
+	}
+
+	// 内部リンク・外部リンクを区別すると外部リンクのテキスト（既定の重み 0）は除外される
+	cfg.DistinguishAnchorTypes = true
+	cfg.ScoreWeights.InternalAnchorText = 3
+	a = NewAnalyzerFromHTML(html, cfg)
+	a.URL = "https://shop.example.com/"
+	keywords, err = a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores = map[string]int{}
+	for _, k := range keywords {
+		scores[k.Keyword] = k.Score
+	}
+	if scores["sandals"] != 3 {
+		t.Errorf("expected internal anchor weight 3, got %v", scores)
+	}
+	if _, ok := scores["partner"]; ok {
+		t.Errorf("external anchor text should be excluded, got %v", scores)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	html := `<html lang="ja"><head><title>東京都</title></head><body><h1>新宿区</h1></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	if lang := a.Language(); lang.Code != LangJapanese {
+		t.Errorf("expected ja from html lang, got %+v", lang)
+	}
+
+	html = `<html><head><title>Kubernetes cluster guide</title></head><body><p>This guide explains how to build a Kubernetes cluster for your team.</p></body></html>`
+	a = NewAnalyzerFromHTML(html, config.DefaultConfig())
+	if lang := a.Language(); lang.Code != LangEnglish || lang.Confidence <= 0 {
+		t.Errorf("expected detected en, got %+v", lang)
+	}
+
+	// Config.Language で判定を上書きできる
+	cfg := config.DefaultConfig()
+	cfg.Language = "de"
+	a = NewAnalyzerFromHTML(html, cfg)
+	if lang := a.Language(); lang.Code != LangGerman || lang.Confidence != 1 {
+		t.Errorf("expected overridden de, got %+v", lang)
+	}
+}
+
+func TestLanguageRouting_EnglishPageWithKanji(t *testing.T) {
+	// 英語ページのタイトルに漢字が1文字あっても日本語の形態素解析に回さない
+	html := `<html><head><title>東 Garden Tour</title></head><body><p>The garden tour starts every morning. Visitors enjoy the garden.</p></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, k := range keywords {
+		if k.Keyword == "東" {
+			t.Errorf("kanji should not be extracted on an English page: %+v", keywords)
+		}
+		if k.Keyword == "garden" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected garden, got %+v", keywords)
+	}
+	result, err := a.GetAnalysisResult(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Language == nil || result.Language.Code != LangEnglish {
+		t.Errorf("expected language en in result, got %+v", result.Language)
+	}
+}
+
+func TestMixedScriptRouting(t *testing.T) {
+	// 日本語の中の英単語は英語の抽出器（ストップワード除去・正規化あり）で処理し、英語のみのテキストと同じキーにまとめる
+	html := `<html lang="ja"><head><title>Kubernetes クラスタの構築</title></head>
+	<body><h1>Kubernetes Clusters</h1><p>本文です。</p></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range keywords {
+		scores[k.Keyword] = k.Score
+	}
+	cfg := a.Config.ScoreWeights
+	if scores["kubernetes"] != cfg.Title+cfg.H1 {
+		t.Errorf("expected kubernetes merged across title and h1 (%d), got %v", cfg.Title+cfg.H1, scores)
+	}
+	if _, ok := scores["Kubernetes"]; ok {
+		t.Errorf("surface form should not be scored separately: %v", scores)
+	}
+	if scores["クラスタ"] == 0 || scores["構築"] == 0 {
+		t.Errorf("expected Japanese nouns from the title, got %v", scores)
+	}
+}
+
+func TestChinesePage(t *testing.T) {
+	html := `<html lang="zh-TW"><head><title>雲計算服務</title><meta name="description" content="我们提供云计算服务和数据库管理。"></head>
+	<body><h1>數據庫管理</h1><p>我们的云计算平台。</p></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	if lang := a.Language(); lang.Code != LangChinese {
+		t.Fatalf("expected zh, got %+v", lang)
+	}
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range keywords {
+		scores[k.Keyword] = k.Score
+	}
+	// 繁体字と簡体字は同じキーワードとして集計する
+	w := a.Config.ScoreWeights
+	if scores["云计算"] != w.Title+w.Description || scores["数据库"] != w.Description+w.H1 {
+		t.Errorf("unexpected scores: %v", scores)
+	}
+	if _, ok := scores["我们"]; ok {
+		t.Errorf("stop words should be excluded: %v", scores)
+	}
+}
+
+func TestChinesePage_Traditional(t *testing.T) {
+	// 繁体字のみのページも簡体字と同じ語に分割し、簡体字のキーで集計する
+	html := `<html lang="zh-TW"><head><title>連接設備與郵件系統</title></head>
+	<body><h1>創新的郵件服務</h1><p>我們提供連接設備的創新方案。</p></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := map[string]bool{}
+	for _, k := range keywords {
+		found[k.Keyword] = true
+	}
+	for _, want := range []string{"连接", "设备", "创新", "邮件"} {
+		if !found[want] {
+			t.Errorf("expected %s in %v", want, keywords)
+		}
+	}
+	for _, single := range []string{"连", "接", "设", "备"} {
+		if found[single] {
+			t.Errorf("expected %s not to be split off: %v", single, keywords)
+		}
+	}
+}
+
+func TestKoreanPage(t *testing.T) {
+	html := `<html><head><title>서울 클라우드 행사</title></head>
+	<body><h1>서울에서 열리는 Kubernetes 행사입니다</h1><p>서울의 개발자들은 클라우드를 사용합니다.</p></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	if lang := a.Language(); lang.Code != LangKorean {
+		t.Fatalf("expected ko, got %+v", lang)
+	}
+	result, err := a.GetAnalysisResult(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range result.Keywords {
+		scores[k.Keyword] = k.Score
+		if k.Prominence == nil {
+			t.Errorf("expected prominence for %s", k.Keyword)
+		}
+	}
+	// 助詞を除いた名詞でまとめる
+	w := a.Config.ScoreWeights
+	if scores["서울"] != w.Title+w.H1 || scores["행사"] != w.Title+w.H1 || scores["kubernetes"] != w.H1 {
+		t.Errorf("unexpected scores: %v", scores)
+	}
+	if _, ok := scores["서울에서"]; ok {
+		t.Errorf("particles should be stripped: %v", scores)
+	}
+}
+
+func TestEuropeanPage(t *testing.T) {
+	html := `<html lang="fr"><head><title>Les entreprises et la croissance</title>
+	<meta name="description" content="Le développement rapide des entreprises françaises."></head>
+	<body><h1>Une entreprise en croissance</h1><p>Nous aidons les entreprises.</p></body></html>`
+	a := NewAnalyzerFromHTML(html, config.DefaultConfig())
+	if lang := a.Language(); lang.Code != LangFrench {
+		t.Fatalf("expected fr, got %+v", lang)
+	}
+	result, err := a.GetAnalysisResult(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range result.Keywords {
+		scores[k.Keyword] = k.Score
+		if k.Prominence == nil {
+			t.Errorf("expected prominence for %s", k.Keyword)
+		}
+	}
+	for _, stop := range []string{"les", "la", "le", "et", "des", "une", "en"} {
+		if _, ok := scores[stop]; ok {
+			t.Errorf("French stop word %q should be excluded: %v", stop, scores)
+		}
+	}
+	// アクセント付きの語も残す
+	if scores["développement"] == 0 || scores["françaises"] == 0 {
+		t.Errorf("expected accented words, got %v", scores)
+	}
+	if scores["croissance"] != a.Config.ScoreWeights.Title+a.Config.ScoreWeights.H1 {
+		t.Errorf("unexpected score for croissance: %v", scores)
+	}
+}
+
+func TestEuropeanPage_StemAcrossSources(t *testing.T) {
+	// 語幹が同じ活用形は出現元をまたいで1つのキーワードにまとめる
+	html := `<html lang="fr"><head><title>Les entreprises françaises</title></head>
+	<body><h1>Une entreprise française</h1></body></html>`
+	cfg := config.DefaultConfig()
+	a := NewAnalyzerFromHTML(html, cfg)
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keywords) != 2 {
+		t.Fatalf("expected 2 keywords, got %v", keywords)
+	}
+	w := cfg.ScoreWeights
+	for _, k := range keywords {
+		if k.Score != w.Title+w.H1 {
+			t.Errorf("expected %s to score title+h1, got %v", k.Keyword, keywords)
+		}
+	}
+}
+
+func TestUnicodeNormalization(t *testing.T) {
+	// 全角英字・半角カタカナを通常の表記と同じキーワードとして集計し、表示には元の表記を使う
+	html := `<html lang="ja"><head><title>ＡＩとｷｰﾜｰﾄﾞ分析</title></head>
+	<body><h1>AIによるキーワード分析</h1></body></html>`
+	cfg := config.DefaultConfig()
+	a := NewAnalyzerFromHTML(html, cfg)
+	result, err := a.GetAnalysisResult(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range result.Keywords {
+		scores[k.Keyword] = k.Score
+		if k.Prominence == nil {
+			t.Errorf("expected prominence for %s", k.Keyword)
+		}
+	}
+	w := cfg.ScoreWeights
+	if scores["ＡＩ"] != w.Title+w.H1 || scores["ｷｰﾜｰﾄﾞ"] != w.Title+w.H1 {
+		t.Errorf("expected width variants merged with original surface, got %v", scores)
+	}
+
+	// 正規化しない場合は別のキーワードになる
+	cfg.UnicodeNormalization = false
+	a = NewAnalyzerFromHTML(html, cfg)
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores = map[string]int{}
+	for _, k := range keywords {
+		scores[k.Keyword] = k.Score
+	}
+	if scores["キーワード"] != w.H1 {
+		t.Errorf("expected separate keywords without normalization, got %v", scores)
+	}
+}
+
+func TestFoldKana(t *testing.T) {
+	html := `<html lang="ja"><head><title>めがね</title></head><body><h1>メガネ</h1></body></html>`
+	cfg := config.DefaultConfig()
+	cfg.FoldKana = true
+	a := NewAnalyzerFromHTML(html, cfg)
+	keywords, err := a.GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keywords) != 1 || keywords[0].Score != cfg.ScoreWeights.Title+cfg.ScoreWeights.H1 {
+		t.Errorf("expected hiragana and katakana merged, got %+v", keywords)
+	}
+}
+
+func TestFoldKana_Sentence(t *testing.T) {
+	// かなの統一は形態素解析の後に行い、文の分割を変えない
+	html := `<html lang="ja"><head><title>東京のおすすめカフェをご紹介します</title></head><body><h1>オススメ</h1></body></html>`
+	cfg := config.DefaultConfig()
+	cfg.FoldKana = true
+	keywords, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := map[string]int{}
+	for _, k := range keywords {
+		scores[k.Keyword] = k.Score
+	}
+	w := cfg.ScoreWeights
+	if scores["東京"] != w.Title || scores["カフェ"] != w.Title || scores["紹介"] != w.Title {
+		t.Errorf("expected the sentence to be tokenized as usual, got %v", scores)
+	}
+	if scores["おすすめ"] != w.Title+w.H1 {
+		t.Errorf("expected おすすめ and オススメ merged, got %v", scores)
+	}
+}
+
+func TestKanaFollowedByHyphen(t *testing.T) {
+	// かなの後の区切りのハイフンは長音符にせず、キーワードの表記にも含めない
+	html := `<html lang="ja"><head><title>カタカナの表記</title></head><body><h1>カタカナ-English</h1></body></html>`
+	keywords, err := NewAnalyzerFromHTML(html, config.DefaultConfig()).GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := map[string]bool{}
+	for _, k := range keywords {
+		found[k.Keyword] = true
+	}
+	if !found["カタカナ"] || !found["english"] || found["カタカナ-"] || found["カタカナー"] {
+		t.Errorf("expected カタカナ and english as separate keywords, got %+v", keywords)
+	}
+}
+
+func TestJapaneseUserDict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "userdict.txt")
+	if err := os.WriteFile(path, []byte("東京スカイツリー,東京 スカイツリー,トウキョウ スカイツリー,カスタム名詞\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	html := `<html lang="ja"><head><title>東京スカイツリーの展望台</title></head><body></body></html>`
+	cfg := config.DefaultConfig()
+	cfg.JapaneseUserDictFile = path
+	keywords, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keywords) == 0 || keywords[0].Keyword != "東京スカイツリー" {
+		t.Errorf("expected the user dictionary term as one keyword, got %+v", keywords)
+	}
+
+	cfg.JapaneseDictionary = "unknown"
+	if _, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(10); err == nil {
+		t.Error("expected error for an unknown dictionary")
+	}
+}
+
+func TestJapaneseFilter(t *testing.T) {
+	html := `<html lang="ja"><head><title>田中さんが東京で新しいカメラを買った</title></head><body></body></html>`
+	cfg := config.DefaultConfig()
+	filter, err := config.JapaneseFilterPreset(config.JapaneseFilterProperNouns, cfg.JapaneseDictionary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.JapaneseFilter = filter
+	keywords, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, k := range keywords {
+		got = append(got, k.Keyword)
+	}
+	if strings.Join(got, ",") != "田中,東京" {
+		t.Errorf("expected only proper nouns, got %v", got)
+	}
+}
+
+func TestJapaneseStopWordsAndReading(t *testing.T) {
+	html := `<html lang="ja"><head><title>お問合せ方法</title></head><body><h1>お問い合わせの情報</h1></body></html>`
+	cfg := config.DefaultConfig()
+	keywords, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, k := range keywords {
+		if k.Keyword == "方法" || k.Keyword == "情報" {
+			t.Errorf("expected Japanese stop words to be removed, got %+v", keywords)
+		}
+	}
+	if len(keywords) != 2 {
+		t.Errorf("expected two spellings without reading merge, got %+v", keywords)
+	}
+
+	cfg.JapaneseMergeByReading = true
+	result, err := NewAnalyzerFromHTML(html, cfg).GetAnalysisResult(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Keywords) != 1 {
+		t.Fatalf("expected spellings merged by reading, got %+v", result.Keywords)
+	}
+	k := result.Keywords[0]
+	if k.Keyword != "問い合わせ" || k.Score != cfg.ScoreWeights.Title+cfg.ScoreWeights.H1 || k.Prominence == nil {
+		t.Errorf("unexpected merged keyword: %+v", k)
+	}
+}
+
+func TestJapaneseMergeByReading_Homophones(t *testing.T) {
+	// 読みが同じでも漢字が異なる語はまとめない
+	html := `<html lang="ja"><head><title>科学と化学</title></head><body><h1>公園での講演と公演</h1></body></html>`
+	cfg := config.DefaultConfig()
+	cfg.JapaneseMergeByReading = true
+	keywords, err := NewAnalyzerFromHTML(html, cfg).GetTopKeywordsAuto(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]bool{}
+	for _, k := range keywords {
+		got[k.Keyword] = true
+	}
+	for _, w := range []string{"科学", "化学", "公園", "講演", "公演"} {
+		if !got[w] {
+			t.Errorf("expected homophone %s as its own keyword, got %+v", w, keywords)
+		}
+	}
+}
+
+func TestKeywordKey(t *testing.T) {
+	cfg := config.DefaultConfig()
+	reading := config.DefaultConfig()
+	reading.JapaneseMergeByReading = true
+	cases := []struct {
+		a, b string
+		lang string
+		cfg  config.Config
+	}{
+		{"cities", "Ｃｉｔｙ", "", cfg},
+		{"entreprises", "Entreprise", LangFrench, cfg},
+		{"數據庫", "数据库", LangChinese, cfg},
+		{"問合せ", "問い合わせ", LangJapanese, reading},
+	}
+	for _, c := range cases {
+		if a, b := KeywordKey(c.a, c.lang, c.cfg), KeywordKey(c.b, c.lang, c.cfg); a != b {
+			t.Errorf("expected %s and %s to share a key, got %q and %q", c.a, c.b, a, b)
+		}
+	}
+	if KeywordKey("科学", LangJapanese, reading) == KeywordKey("化学", LangJapanese, reading) {
+		t.Error("expected homophones to have different keys")
+	}
+}
+
+func TestAuditFindings(t *testing.T) {
+	html := `<html><head><title>Sneakers</title></head><body>
+	<h1>Sneakers sale</h1><h1>Sneakers</h1>
+	<img src="hero.jpg"><img src="logo.png" alt="">
+	` + strings.Repeat(`<a href="/sneakers">Sneakers</a>`, 10) + `
+	</body></html>`
+	cfg := config.DefaultConfig()
+	a := NewAnalyzerFromHTML(html, cfg)
+	a.URL = "https://example.com/shop"
+	a.xRobotsTag = "googlebot: noindex"
+	result, err := a.GetAnalysisResult(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rules []string
+	for _, f := range result.Findings {
+		rules = append(rules, f.Rule)
+	}
+	expected := []string{
+		config.AuditTitleLength, config.AuditMissingDescription, config.AuditMultipleH1,
+		config.AuditKeywordStuffing, config.AuditImageAlt, config.AuditNoindex,
+	}
+	if strings.Join(rules, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected rules %v, got %+v", expected, result.Findings)
+	}
+
+	cfg.Audit.Disable(config.AuditKeywordStuffing, config.AuditNoindex)
+	result, err = NewAnalyzerFromHTML(html, cfg).GetAnalysisResult(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, f := range result.Findings {
+		if f.Rule == config.AuditKeywordStuffing || f.Rule == config.AuditNoindex {
+			t.Errorf("expected disabled rule to be skipped, got %+v", f)
+		}
+	}
+
+	cfg.Audit = config.AuditConfig{}
+	result, err = NewAnalyzerFromHTML(html, cfg).GetAnalysisResult(5)
+	if err != nil || result.Findings != nil {
+		t.Errorf("expected no findings with audit disabled, got %+v, %v", result.Findings, err)
+	}
+}
+
+func TestAuditFindings_LanguageAndKeys(t *testing.T) {
+	findings := func(html string) map[string]string {
+		result, err := NewAnalyzerFromHTML(html, config.DefaultConfig()).GetAnalysisResult(5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rules := map[string]string{}
+		for _, f := range result.Findings {
+			rules[f.Rule] = f.Message
+		}
+		return rules
+	}
+
+	// 日本語のページは日本語向けの文字数で確認する（英語の 30〜60 文字では短すぎると指摘される）
+	ja := findings(`<html lang="ja"><head><title>東京のおすすめカフェ特集｜カフェガイド</title>
+	<meta name="description" content="東京で人気のおすすめカフェを紹介します。駅から近いカフェや静かに作業できるカフェ、朝早くから営業しているカフェまで。"></head>
+	<body><h1>東京のおすすめカフェ</h1><p>東京のカフェを紹介します。カフェでゆっくり過ごしましょう。</p></body></html>`)
+	if msg, ok := ja[config.AuditTitleLength]; ok {
+		t.Errorf("unexpected title finding for Japanese page: %s", msg)
+	}
+	if msg, ok := ja[config.AuditDescriptionLength]; ok {
+		t.Errorf("unexpected description finding for Japanese page: %s", msg)
+	}
+
+	// 1位のキーワードは集計キーで照合する（"entreprises" は title の "Entreprise" と語幹が一致）
+	fr := findings(`<html lang="fr"><head><title>Entreprise</title>
+	<meta name="description" content="Les entreprises innovantes et les entreprises locales"></head>
+	<body><h1>Guide</h1></body></html>`)
+	if msg, ok := fr[config.AuditKeywordPlacement]; ok {
+		t.Errorf("unexpected keyword placement finding: %s", msg)
+	}
+	if _, ok := fr[config.AuditTitleLength]; !ok {
+		t.Error("expected title length finding for a short French title")
+	}
+}
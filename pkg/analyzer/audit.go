@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/xshoji/go-site-keyword/internal/audit"
+	"github.com/xshoji/go-site-keyword/internal/parser"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// AuditContext は Config.Audit で有効なルールでページを監査します（keywords は GetTopKeywordsAutoContext などの結果）
+func (a *Analyzer) AuditContext(ctx context.Context, keywords []types.KeywordWithScore) ([]types.Finding, error) {
+	if !a.auditEnabled() {
+		return nil, nil
+	}
+	prominence, err := a.KeywordProminenceContext(ctx, a.Config.EnglishStopWords, a.normalizeFunc())
+	if err != nil {
+		return nil, err
+	}
+	return a.audit(ctx, keywords, prominence)
+}
+
+// auditEnabled は監査ルールが1つでも有効なら true を返します
+func (a *Analyzer) auditEnabled() bool {
+	c := a.Config.Audit
+	return c.TitleLength.Enabled || c.DescriptionLength.Enabled || c.MissingDescription.Enabled ||
+		c.MissingH1.Enabled || c.MultipleH1.Enabled || c.KeywordPlacement.Enabled ||
+		c.KeywordStuffing.Enabled || c.ImageAlt.Enabled || c.Noindex.Enabled
+}
+
+// audit は KeywordProminenceContext の集計から出現回数を求めて監査します
+// title・説明文は DocumentParser、h1・画像・robots は読み込んだ文書から取得します
+// 文字数の推奨範囲はページの言語で選び、キーワードの配置は集計キーで照合します
+func (a *Analyzer) audit(ctx context.Context, keywords []types.KeywordWithScore, prominence map[string]*types.KeywordProminence) ([]types.Finding, error) {
+	doc, err := a.document()
+	if err != nil {
+		return nil, err
+	}
+	title, _ := a.FetchTitle()
+	meta, _ := a.FetchMetaTags()
+	page := audit.Page{
+		URL:              a.URL,
+		Title:            title,
+		Description:      meta["description"],
+		ImagesWithoutAlt: doc.FetchImagesWithoutAlt(),
+		Robots:           append(doc.FetchRobotsDirectives(), parser.ParseRobotsDirectives(a.xRobotsTag)...),
+	}
+	for _, heading := range doc.FetchHeadings() {
+		if heading.Level == 1 {
+			page.H1 = append(page.H1, heading.Text)
+		}
+	}
+	for _, p := range prominence {
+		page.TotalCount += sumCounts(p)
+	}
+	normalizeKeyword := a.normalizeFunc()
+	for _, kw := range keywords {
+		page.Keywords = append(page.Keywords, audit.Keyword{
+			Keyword: kw.Keyword,
+			Count:   sumCounts(prominence[a.keywordKey(kw.Keyword, normalizeKeyword)]),
+		})
+	}
+	page.Keys = func(text string) []string {
+		tokens, err := a.tokenizeKeywordsContext(ctx, text, a.Config.EnglishStopWords, normalizeKeyword)
+		if err != nil {
+			return nil
+		}
+		keys := make([]string, 0, len(tokens))
+		for _, tok := range tokens {
+			keys = append(keys, tok.norm)
+		}
+		return keys
+	}
+	cfg := a.Config.Audit
+	lang := a.Language().Code
+	cfg.TitleLength = cfg.TitleLength.ForLanguage(lang)
+	cfg.DescriptionLength = cfg.DescriptionLength.ForLanguage(lang)
+	findings := audit.Run(page, cfg)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func sumCounts(p *types.KeywordProminence) int {
+	if p == nil {
+		return 0
+	}
+	n := 0
+	for _, c := range p.Counts {
+		n += c
+	}
+	return n
+}
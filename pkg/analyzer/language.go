@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/xshoji/go-site-keyword/internal/language"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// Language はページの言語を返します
+// Config.Language が指定されていればそれを使い、なければ本文の文字種・文字 trigram と
+// <html lang>・Content-Language ヘッダーを手がかりに判定します
+func (a *Analyzer) Language() types.LanguageDetection {
+	if a.language != nil {
+		return *a.language
+	}
+	var result types.LanguageDetection
+	if a.Config.Language != "" {
+		result = types.LanguageDetection{Code: strings.ToLower(a.Config.Language), Confidence: 1}
+	} else if a.doc == nil {
+		// 文書が読み込まれていない場合は判定しない（結果も保持しない）
+		return types.LanguageDetection{Code: language.Undetermined}
+	} else {
+		// 言語は文書の性質のため、DocumentParser を差し替えた場合も文書全体のテキストで判定する
+		var texts []string
+		for _, block := range a.doc.FetchTextBlocks() {
+			texts = append(texts, block.Text)
+		}
+		detected := language.DetectWithHints(strings.Join(texts, "\n"), language.Hints{
+			HTMLLang:        a.doc.FetchHTMLLang(),
+			ContentLanguage: a.contentLanguage,
+		})
+		result = types.LanguageDetection{Code: detected.Lang, Confidence: detected.Confidence}
+	}
+	a.language = &result
+	return result
+}
+
+// languageSegment は抽出器に渡すテキストの区間とその言語
+type languageSegment struct {
+	lang string
+	text string
+}
+
+// splitByLanguage はテキストを文字種ごとの区間に分け、それぞれの抽出に使う言語を割り当てます
+// （例: 日本語ページの "Kubernetes クラスタの構築" → "Kubernetes" は英語、"クラスタの構築" は日本語）
+func (a *Analyzer) splitByLanguage(text string) []languageSegment {
+	page := a.Language().Code
+	textLang := ""
+	var result []languageSegment
+	for _, run := range language.SplitScriptRuns(text) {
+		lang := LangEnglish
+		switch run.Script {
+		case language.ScriptHangul:
+			lang = LangKorean
+		case language.ScriptCJK:
+			if language.IsCJK(page) {
+				lang = page
+				break
+			}
+			// CJK 以外のページではテキスト全体で判定し、ラテン文字が主体なら漢字・かなは英語の抽出器に回す
+			// （英語ページのタイトルに漢字が1文字ある場合など）
+			if textLang == "" {
+				textLang = language.Detect(text).Lang
+			}
+			if language.IsCJK(textLang) {
+				lang = textLang
+			}
+		default:
+			if !language.IsCJK(page) && page != language.Undetermined {
+				lang = page
+			}
+		}
+		result = append(result, languageSegment{lang: lang, text: run.Text})
+	}
+	return result
+}
+
+// normalizeText はキーワード抽出前の正規化（NFKC・ダッシュの統一）を行います
+// Config.UnicodeNormalization が false ならテキストをそのまま返します
+// ひらがな・カタカナの統一は形態素解析の結果が変わるため、ここでは行わず foldKey で集計キーに適用します
+func (a *Analyzer) normalizeText(text string) *language.NormalizedText {
+	if !a.Config.UnicodeNormalization {
+		return &language.NormalizedText{Text: text}
+	}
+	return language.Normalize(text, language.NormalizeOptions{})
+}
+
+// foldKey は Config.FoldKana が有効なら日本語の集計キーのひらがなをカタカナにそろえます
+func (a *Analyzer) foldKey(key string) string {
+	if !a.Config.UnicodeNormalization || !a.Config.FoldKana {
+		return key
+	}
+	return language.FoldKana(key)
+}
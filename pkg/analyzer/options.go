@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"net/http"
+
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// 言語コード（WithExtractor, config.Config.Language で使用）
+const (
+	LangEnglish    = "en"
+	LangJapanese   = "ja"
+	LangChinese    = "zh"
+	LangKorean     = "ko"
+	LangGerman     = "de"
+	LangFrench     = "fr"
+	LangSpanish    = "es"
+	LangItalian    = "it"
+	LangPortuguese = "pt"
+)
+
+// Option は New に渡す Analyzer の設定関数です
+type Option func(*Analyzer)
+
+// WithConfig は設定を差し替えます（未指定時は config.DefaultConfig()）
+func WithConfig(cfg config.Config) Option {
+	return func(a *Analyzer) {
+		a.Config = cfg
+	}
+}
+
+// WithFetcher はページ取得処理を差し替えます
+// f が types.ContextPageFetcher も実装していれば context 付きで呼び出します
+func WithFetcher(f types.PageFetcher) Option {
+	return func(a *Analyzer) {
+		a.fetcher = f
+	}
+}
+
+// WithParser はタイトル・メタタグ・本文の解析処理を差し替えます
+func WithParser(p types.DocumentParser) Option {
+	return func(a *Analyzer) {
+		a.parser = p
+	}
+}
+
+// WithExtractor は指定言語（LangEnglish, LangJapanese など）のキーワード抽出処理を差し替えます
+func WithExtractor(lang string, e types.KeywordExtractor) Option {
+	return func(a *Analyzer) {
+		if a.extractors == nil {
+			a.extractors = map[string]types.KeywordExtractor{}
+		}
+		a.extractors[lang] = e
+	}
+}
+
+// WithScorer はキーワードのランク付け処理を差し替えます
+func WithScorer(s types.KeywordScorer) Option {
+	return func(a *Analyzer) {
+		a.scorer = s
+	}
+}
+
+// WithHTTPClient は組み込みの取得処理で使う HTTP クライアントを指定します
+// （WithFetcher と併用した場合は WithFetcher が優先されます）
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *Analyzer) {
+		a.httpClient = c
+	}
+}
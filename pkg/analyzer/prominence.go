@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xshoji/go-site-keyword/internal/language"
+	"github.com/xshoji/go-site-keyword/internal/language/chinese"
+	"github.com/xshoji/go-site-keyword/internal/language/european"
+	"github.com/xshoji/go-site-keyword/internal/parser"
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// KeywordProminenceContext はページ内のキーワード候補ごとに出現位置・出現元を集計します
+// 戻り値のキーは keywordKey で求めた値です
+func (a *Analyzer) KeywordProminenceContext(ctx context.Context, stopWords map[string]int, normalizeKeyword func(string) string) (map[string]*types.KeywordProminence, error) {
+	blocks, err := a.textBlocks()
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]*types.KeywordProminence{}
+	for _, block := range blocks {
+		tokens, err := a.tokenizeKeywordsContext(ctx, block.Text, stopWords, normalizeKeyword)
+		if err != nil {
+			return nil, err
+		}
+		lowerText := strings.ToLower(block.Text)
+		cursor := 0
+		for _, tok := range tokens {
+			// ブロック内の文字位置（見つからなければ直前のトークンの位置）
+			surface := strings.ToLower(tok.surface)
+			if idx := strings.Index(lowerText[cursor:], surface); idx >= 0 {
+				cursor += idx
+			}
+			offset := block.Offset + utf8.RuneCountInString(lowerText[:cursor])
+
+			p, ok := result[tok.norm]
+			if !ok {
+				p = &types.KeywordProminence{FirstOffset: offset, DOMPath: block.DOMPath, Counts: map[string]int{}}
+				result[tok.norm] = p
+			}
+			if p.Counts[block.Source] == 0 {
+				p.Sources = append(p.Sources, block.Source)
+			}
+			p.Counts[block.Source]++
+		}
+	}
+	return result, nil
+}
+
+// textBlocks はページ内のテキストを出現元ごとのブロックとして文書順に返します
+// DocumentParser を差し替えた場合はその title・説明文・本文をブロックにします
+func (a *Analyzer) textBlocks() ([]parser.TextBlock, error) {
+	doc, err := a.document()
+	if err != nil {
+		return nil, err
+	}
+	if a.parser == nil {
+		return doc.FetchTextBlocks(), nil
+	}
+	title, _ := a.FetchTitle()
+	meta, _ := a.FetchMetaTags()
+	content, _ := a.FetchMainContent()
+	var blocks []parser.TextBlock
+	offset := 0
+	for _, b := range []parser.TextBlock{
+		{Source: parser.SourceTitle, Text: title, DOMPath: "html > head > title"},
+		{Source: parser.SourceMeta, Text: meta["keywords"], DOMPath: "html > head > meta"},
+		{Source: parser.SourceMeta, Text: meta["description"], DOMPath: "html > head > meta"},
+		{Source: parser.SourceBody, Text: content, DOMPath: "html > body"},
+	} {
+		b.Text = strings.TrimSpace(b.Text)
+		if b.Text == "" {
+			continue
+		}
+		b.Offset = offset
+		blocks = append(blocks, b)
+		offset += utf8.RuneCountInString(b.Text) + 1
+	}
+	return blocks, nil
+}
+
+// KeywordKey は表示用のキーワードから解析時と同じ集計キー（正規化・語幹・読みなど）を求めます
+// ページ間・実行間でキーワードを対応づけるために使います。lang はページの言語コード（空なら cfg.Language）
+func KeywordKey(keyword, lang string, cfg config.Config) string {
+	if lang != "" {
+		cfg.Language = lang
+	}
+	a := &Analyzer{Config: cfg}
+	return a.keywordKey(keyword, a.normalizeFunc())
+}
+
+// keywordKey は表示用のキーワードから KeywordProminenceContext の集計キーを求めます
+func (a *Analyzer) keywordKey(keyword string, normalizeKeyword func(string) string) string {
+	keyword = a.normalizeText(keyword).Text
+	lower := strings.ToLower(keyword)
+	if segs := a.splitByLanguage(keyword); len(segs) == 1 {
+		switch {
+		case segs[0].lang == LangJapanese && a.Config.JapaneseMergeByReading:
+			if t, err := a.japaneseTokenizer(); err == nil {
+				return t.MergeKey(keyword)
+			}
+		case segs[0].lang == LangChinese:
+			// 中国語は簡体字にそろえたキーで集計している
+			return chinese.ToSimplified(lower)
+		}
+	}
+	if language.ContainsJapanese(keyword) {
+		return a.foldKey(lower)
+	}
+	if language.ContainsHangul(keyword) {
+		return lower
+	}
+	if lang := a.Language().Code; european.IsSupported(lang) {
+		return european.Stem(lower, lang)
+	}
+	return normalizeKeyword(lower)
+}
+
+// applyProminenceBoost は h1 に現れる語・ページ冒頭に現れる語のスコアを加算します
+func (a *Analyzer) applyProminenceBoost(ctx context.Context, scoreMap map[string]int, stopWords map[string]int, normalizeKeyword func(string) string) error {
+	cfg := a.Config
+	prominence, err := a.KeywordProminenceContext(ctx, stopWords, normalizeKeyword)
+	if err != nil {
+		return err
+	}
+	for key := range scoreMap {
+		p, ok := prominence[a.keywordKey(key, normalizeKeyword)]
+		if !ok {
+			continue
+		}
+		if p.Counts[parser.SourceH1] > 0 {
+			scoreMap[key] += cfg.ScoreWeights.H1Boost
+		}
+		if p.FirstOffset < cfg.EarlyPositionChars {
+			scoreMap[key] += cfg.ScoreWeights.EarlyPositionBoost
+		}
+	}
+	return nil
+}
+
+// attachProminence は各キーワードに出現位置・出現元を設定し、集計結果（監査用）を返します
+func (a *Analyzer) attachProminence(ctx context.Context, keywords []types.KeywordWithScore, stopWords map[string]int, normalizeKeyword func(string) string) (map[string]*types.KeywordProminence, error) {
+	prominence, err := a.KeywordProminenceContext(ctx, stopWords, normalizeKeyword)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keywords {
+		if p, ok := prominence[a.keywordKey(keywords[i].Keyword, normalizeKeyword)]; ok {
+			keywords[i].Prominence = p
+		}
+	}
+	return prominence, nil
+}
@@ -1,6 +1,12 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/xshoji/go-site-keyword/internal/fetcher"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
 
 // デフォルト英語ストップワード
 var DefaultEnglishStopWords = map[string]int{
@@ -30,6 +36,90 @@ var DefaultEnglishStopWords = map[string]int{
 	"well": 0, "oh": 0, "hey": 0, "hi": 0, "hello": 0, "hmm": 0, "uh": 0, "um": 0, "ah": 0, "like": 0, "okay": 0, "ok": 0, "alright": 0, "right": 0, "yeah": 0, "nope": 0, "yep": 0, "huh": 0, "hurray": 0, "oops": 0, "wow": 0, "gee": 0, "gosh": 0, "whoa": 0,
 }
 
+// デフォルトのヨーロッパ言語（ドイツ語・フランス語・スペイン語・イタリア語・ポルトガル語）のストップワード
+var DefaultEuropeanStopWords = map[string]map[string]int{
+	"de": {
+		"der": 0, "die": 0, "das": 0, "den": 0, "dem": 0, "des": 0, "ein": 0, "eine": 0, "einer": 0,
+		"eines": 0, "einem": 0, "einen": 0, "und": 0, "oder": 0, "aber": 0, "doch": 0, "sondern": 0,
+		"denn": 0, "in": 0, "im": 0, "ins": 0, "an": 0, "am": 0, "auf": 0, "aus": 0, "bei": 0, "mit": 0,
+		"nach": 0, "von": 0, "vom": 0, "zu": 0, "zum": 0, "zur": 0, "für": 0, "über": 0, "unter": 0,
+		"vor": 0, "hinter": 0, "neben": 0, "zwischen": 0, "durch": 0, "gegen": 0, "ohne": 0, "um": 0,
+		"bis": 0, "seit": 0, "ist": 0, "sind": 0, "war": 0, "waren": 0, "sein": 0, "bin": 0, "bist": 0,
+		"seid": 0, "wird": 0, "werden": 0, "wurde": 0, "wurden": 0, "hat": 0, "haben": 0, "hatte": 0,
+		"hatten": 0, "kann": 0, "können": 0, "muss": 0, "müssen": 0, "soll": 0, "sollen": 0, "will": 0,
+		"wollen": 0, "nicht": 0, "kein": 0, "keine": 0, "auch": 0, "noch": 0, "nur": 0, "schon": 0,
+		"sehr": 0, "so": 0, "wie": 0, "als": 0, "wenn": 0, "dass": 0, "ob": 0, "weil": 0, "da": 0, "dann": 0,
+		"hier": 0, "dort": 0, "ich": 0, "du": 0, "er": 0, "sie": 0, "es": 0, "wir": 0, "ihr": 0, "man": 0,
+		"sich": 0, "mein": 0, "meine": 0, "dein": 0, "ihre": 0, "unser": 0, "euer": 0, "dieser": 0,
+		"diese": 0, "dieses": 0, "jeder": 0, "jede": 0, "alle": 0, "viele": 0, "mehr": 0, "was": 0,
+		"wer": 0, "wo": 0, "wann": 0, "warum": 0,
+	},
+	"fr": {
+		"le": 0, "la": 0, "les": 0, "l": 0, "un": 0, "une": 0, "des": 0, "du": 0, "de": 0, "d": 0, "et": 0,
+		"ou": 0, "mais": 0, "donc": 0, "car": 0, "ni": 0, "que": 0, "qui": 0, "quoi": 0, "dont": 0,
+		"où": 0, "ce": 0, "cet": 0, "cette": 0, "ces": 0, "c": 0, "il": 0, "elle": 0, "ils": 0, "elles": 0,
+		"on": 0, "nous": 0, "vous": 0, "je": 0, "tu": 0, "j": 0, "me": 0, "te": 0, "se": 0, "s": 0,
+		"lui": 0, "leur": 0, "leurs": 0, "son": 0, "sa": 0, "ses": 0, "mon": 0, "ma": 0, "mes": 0, "ton": 0,
+		"ta": 0, "tes": 0, "notre": 0, "nos": 0, "votre": 0, "vos": 0, "est": 0, "sont": 0, "était": 0,
+		"être": 0, "a": 0, "ont": 0, "avait": 0, "avoir": 0, "fait": 0, "faire": 0, "pas": 0, "ne": 0,
+		"n": 0, "plus": 0, "très": 0, "bien": 0, "aussi": 0, "comme": 0, "avec": 0, "pour": 0, "par": 0,
+		"dans": 0, "sur": 0, "sous": 0, "en": 0, "au": 0, "aux": 0, "entre": 0, "vers": 0, "chez": 0,
+		"sans": 0, "si": 0, "tout": 0, "tous": 0, "toute": 0, "toutes": 0, "y": 0, "même": 0, "autre": 0,
+		"autres": 0, "peut": 0,
+	},
+	"es": {
+		"el": 0, "la": 0, "los": 0, "las": 0, "lo": 0, "un": 0, "una": 0, "unos": 0, "unas": 0, "de": 0,
+		"del": 0, "al": 0, "a": 0, "en": 0, "y": 0, "e": 0, "o": 0, "u": 0, "pero": 0, "sino": 0, "que": 0,
+		"quien": 0, "cual": 0, "cuyo": 0, "donde": 0, "cuando": 0, "como": 0, "por": 0, "para": 0, "con": 0,
+		"sin": 0, "sobre": 0, "entre": 0, "hasta": 0, "desde": 0, "hacia": 0, "es": 0, "son": 0, "era": 0,
+		"eran": 0, "ser": 0, "estar": 0, "está": 0, "están": 0, "fue": 0, "ha": 0, "han": 0, "había": 0,
+		"hay": 0, "tiene": 0, "tienen": 0, "no": 0, "ni": 0, "más": 0, "muy": 0, "ya": 0, "también": 0,
+		"se": 0, "le": 0, "les": 0, "me": 0, "te": 0, "nos": 0, "os": 0, "su": 0, "sus": 0, "mi": 0,
+		"mis": 0, "tu": 0, "tus": 0, "nuestro": 0, "nuestra": 0, "este": 0, "esta": 0, "estos": 0, "estas": 0,
+		"ese": 0, "esa": 0, "esos": 0, "esas": 0, "eso": 0, "esto": 0, "aquel": 0, "todo": 0, "todos": 0,
+		"toda": 0, "todas": 0, "otro": 0, "otra": 0, "otros": 0, "otras": 0, "puede": 0,
+	},
+	"it": {
+		"il": 0, "lo": 0, "la": 0, "i": 0, "gli": 0, "le": 0, "un": 0, "uno": 0, "una": 0, "di": 0,
+		"del": 0, "dello": 0, "della": 0, "dei": 0, "degli": 0, "delle": 0, "a": 0, "al": 0, "allo": 0,
+		"alla": 0, "ai": 0, "agli": 0, "alle": 0, "da": 0, "dal": 0, "dalla": 0, "dai": 0, "in": 0,
+		"nel": 0, "nella": 0, "nei": 0, "nelle": 0, "su": 0, "sul": 0, "sulla": 0, "con": 0, "per": 0,
+		"tra": 0, "fra": 0, "e": 0, "ed": 0, "o": 0, "ma": 0, "che": 0, "chi": 0, "cui": 0, "dove": 0,
+		"quando": 0, "come": 0, "non": 0, "più": 0, "molto": 0, "anche": 0, "già": 0, "se": 0, "si": 0,
+		"ci": 0, "vi": 0, "mi": 0, "ti": 0, "ne": 0, "è": 0, "sono": 0, "era": 0, "erano": 0, "essere": 0,
+		"ha": 0, "hanno": 0, "avere": 0, "aveva": 0, "suo": 0, "sua": 0, "suoi": 0, "sue": 0, "mio": 0,
+		"mia": 0, "tuo": 0, "tua": 0, "nostro": 0, "nostra": 0, "questo": 0, "questa": 0, "questi": 0,
+		"queste": 0, "quello": 0, "quella": 0, "tutto": 0, "tutti": 0, "tutta": 0, "tutte": 0, "altro": 0,
+		"altri": 0, "altra": 0, "può": 0, "l": 0,
+	},
+	"pt": {
+		"o": 0, "a": 0, "os": 0, "as": 0, "um": 0, "uma": 0, "uns": 0, "umas": 0, "de": 0, "do": 0,
+		"da": 0, "dos": 0, "das": 0, "em": 0, "no": 0, "na": 0, "nos": 0, "nas": 0, "por": 0, "pelo": 0,
+		"pela": 0, "pelos": 0, "pelas": 0, "para": 0, "com": 0, "sem": 0, "sobre": 0, "entre": 0, "até": 0,
+		"e": 0, "ou": 0, "mas": 0, "que": 0, "quem": 0, "qual": 0, "cujo": 0, "onde": 0, "quando": 0,
+		"como": 0, "não": 0, "nem": 0, "mais": 0, "muito": 0, "já": 0, "também": 0, "se": 0, "lhe": 0,
+		"lhes": 0, "me": 0, "te": 0, "é": 0, "são": 0, "era": 0, "eram": 0, "ser": 0, "estar": 0, "está": 0,
+		"estão": 0, "foi": 0, "tem": 0, "têm": 0, "ter": 0, "há": 0, "seu": 0, "sua": 0, "seus": 0,
+		"suas": 0, "meu": 0, "minha": 0, "teu": 0, "tua": 0, "nosso": 0, "nossa": 0, "este": 0, "esta": 0,
+		"estes": 0, "estas": 0, "esse": 0, "essa": 0, "esses": 0, "essas": 0, "isso": 0, "isto": 0,
+		"aquele": 0, "todo": 0, "todos": 0, "toda": 0, "todas": 0, "outro": 0, "outra": 0, "outros": 0,
+		"outras": 0, "pode": 0, "ao": 0, "aos": 0, "à": 0, "às": 0,
+	},
+}
+
+// デフォルト日本語ストップワード（どのページにも現れやすい一般的な名詞・形式名詞）
+var DefaultJapaneseStopWords = map[string]int{
+	"こと": 0, "もの": 0, "ため": 0, "よう": 0, "ところ": 0, "とき": 0, "ほう": 0, "うち": 0,
+	"ほか": 0, "たち": 0, "など": 0, "これ": 0, "それ": 0, "あれ": 0, "どれ": 0, "ここ": 0,
+	"そこ": 0, "どこ": 0, "こちら": 0, "そちら": 0, "あちら": 0, "どちら": 0,
+	"場合": 0, "方法": 0, "情報": 0, "内容": 0, "詳細": 0, "一覧": 0, "以上": 0, "以下": 0,
+	"以外": 0, "上記": 0, "下記": 0, "前後": 0, "今回": 0, "今後": 0, "必要": 0, "可能": 0,
+	"様々": 0, "色々": 0, "全て": 0, "すべて": 0, "各種": 0, "関連": 0, "対象": 0, "状況": 0,
+	"部分": 0, "時間": 0, "自分": 0, "皆様": 0, "みなさま": 0, "お客様": 0, "当社": 0, "弊社": 0,
+	"方": 0, "時": 0, "中": 0, "上": 0, "下": 0, "前": 0, "後": 0, "他": 0, "等": 0, "際": 0,
+	"点": 0, "的": 0, "今": 0, "本": 0, "者": 0, "化": 0,
+}
+
 // 単複変換マップ
 var DefaultPluralSingularMap = map[string]string{
 	"men": "man", "women": "woman", "children": "child",
@@ -65,40 +155,343 @@ var DefaultInvariantWords = map[string]bool{
 	"advice": true, "knowledge": true, "research": true, "data": true,
 }
 
+// JapaneseFilterConfig は日本語のキーワード候補の条件
+type JapaneseFilterConfig struct {
+	// Allow は候補とする品詞（"名詞,固有名詞" のように品詞・品詞細分類1… をカンマ区切りで指定、"*" は任意）
+	// いずれかに一致すれば候補とする。空なら辞書ごとの既定（一般名詞・固有名詞など）
+	Allow []string
+	// Deny は除外する品詞（Allow より優先）
+	Deny []string
+	// BaseForm が true なら活用する語（動詞・形容詞など）を基本形で数える
+	BaseForm bool
+	// MinLength は候補とする最小文字数
+	MinLength int
+	// KanjiMinLength は漢字のみの語の最小文字数
+	KanjiMinLength int
+}
+
+// 日本語の品詞フィルタのプリセット名
+const (
+	// JapaneseFilterDefault は一般名詞・固有名詞などの名詞（1文字の語は漢字のみ）
+	JapaneseFilterDefault = "default"
+	// JapaneseFilterProperNouns は固有名詞のみ（エンティティ抽出向け）
+	JapaneseFilterProperNouns = "proper-nouns"
+	// JapaneseFilterIntent は名詞に加えて動詞・形容詞を基本形で数える（検索意図の分析向け）
+	JapaneseFilterIntent = "intent"
+)
+
+// DefaultJapaneseFilter は既定の日本語の品詞フィルタ
+func DefaultJapaneseFilter() JapaneseFilterConfig {
+	return JapaneseFilterConfig{MinLength: 2, KanjiMinLength: 1}
+}
+
+// JapaneseFilterPreset は辞書（"ipa", "uni"）に合わせた品詞フィルタのプリセットを返します
+func JapaneseFilterPreset(name, dictionary string) (JapaneseFilterConfig, error) {
+	f := DefaultJapaneseFilter()
+	switch name {
+	case JapaneseFilterDefault:
+	case JapaneseFilterProperNouns:
+		f.Allow = []string{"名詞,固有名詞"}
+	case JapaneseFilterIntent:
+		if dictionary == "uni" {
+			f.Allow = []string{"名詞,普通名詞", "名詞,固有名詞", "動詞,一般", "形容詞,一般"}
+		} else {
+			f.Allow = []string{"名詞,一般", "名詞,固有名詞", "名詞,サ変接続", "名詞,形容動詞語幹", "動詞,自立", "形容詞,自立"}
+		}
+		f.BaseForm = true
+	default:
+		return f, fmt.Errorf("unknown Japanese filter preset '%s'", name)
+	}
+	return f, nil
+}
+
+// 監査ルール名
+const (
+	AuditTitleLength        = "title-length"
+	AuditDescriptionLength  = "description-length"
+	AuditMissingDescription = "missing-description"
+	AuditMissingH1          = "missing-h1"
+	AuditMultipleH1         = "multiple-h1"
+	AuditKeywordPlacement   = "keyword-placement"
+	AuditKeywordStuffing    = "keyword-stuffing"
+	AuditImageAlt           = "image-alt"
+	AuditNoindex            = "noindex"
+)
+
+// AuditRuleNames はすべての監査ルール名（実行順）
+var AuditRuleNames = []string{
+	AuditTitleLength, AuditDescriptionLength, AuditMissingDescription, AuditMissingH1, AuditMultipleH1,
+	AuditKeywordPlacement, AuditKeywordStuffing, AuditImageAlt, AuditNoindex,
+}
+
+// AuditRule は監査ルールの共通設定
+type AuditRule struct {
+	// Enabled が false ならルールを実行しない
+	Enabled bool
+	// Severity は指摘の重要度（"info", "warning", "error"）
+	Severity string
+}
+
+// AuditLength は推奨する文字数の範囲（0 なら確認しない）
+type AuditLength struct {
+	Min int
+	Max int
+}
+
+// AuditLengthRule は文字数が推奨範囲にあるか確認するルールの設定
+type AuditLengthRule struct {
+	AuditRule
+	// Min / Max は推奨する文字数（0 なら確認しない）
+	Min int
+	Max int
+	// Languages は言語コードごとの推奨する文字数（ページの言語があれば Min / Max の代わりに使う）
+	Languages map[string]AuditLength
+}
+
+// ForLanguage は言語コード（小文字）に応じた Min / Max を設定したルールを返します
+func (r AuditLengthRule) ForLanguage(lang string) AuditLengthRule {
+	if l, ok := r.Languages[lang]; ok {
+		r.Min, r.Max = l.Min, l.Max
+	}
+	return r
+}
+
+// AuditDensityRule はキーワードの詰め込みを確認するルールの設定
+type AuditDensityRule struct {
+	AuditRule
+	// MaxDensity はキーワード候補の出現回数の合計に占める1語の割合の上限（0〜1）
+	MaxDensity float64
+	// MinCount はこの回数未満の語は確認しない（短いページでの誤検知を防ぐ）
+	MinCount int
+}
+
+// AuditConfig はオンページSEO監査のルールごとの設定（ゼロ値ならすべて無効）
+type AuditConfig struct {
+	// TitleLength は title の文字数（空の title も指摘する）
+	TitleLength AuditLengthRule
+	// DescriptionLength は meta description の文字数（description がない場合は MissingDescription）
+	DescriptionLength AuditLengthRule
+	// MissingDescription は meta description がないページ
+	MissingDescription AuditRule
+	// MissingH1 は h1 がないページ
+	MissingH1 AuditRule
+	// MultipleH1 は h1 が複数あるページ
+	MultipleH1 AuditRule
+	// KeywordPlacement は1位のキーワードが title・h1・URL のいずれにも含まれないページ
+	KeywordPlacement AuditRule
+	// KeywordStuffing は出現割合が高すぎるキーワード
+	KeywordStuffing AuditDensityRule
+	// ImageAlt は alt 属性のない img
+	ImageAlt AuditRule
+	// Noindex は meta robots・X-Robots-Tag の noindex / none
+	Noindex AuditRule
+}
+
+// Rule は名前に対応するルールの共通設定を返します（Enabled・Severity の変更用）
+func (c *AuditConfig) Rule(name string) (*AuditRule, error) {
+	switch name {
+	case AuditTitleLength:
+		return &c.TitleLength.AuditRule, nil
+	case AuditDescriptionLength:
+		return &c.DescriptionLength.AuditRule, nil
+	case AuditMissingDescription:
+		return &c.MissingDescription, nil
+	case AuditMissingH1:
+		return &c.MissingH1, nil
+	case AuditMultipleH1:
+		return &c.MultipleH1, nil
+	case AuditKeywordPlacement:
+		return &c.KeywordPlacement, nil
+	case AuditKeywordStuffing:
+		return &c.KeywordStuffing.AuditRule, nil
+	case AuditImageAlt:
+		return &c.ImageAlt, nil
+	case AuditNoindex:
+		return &c.Noindex, nil
+	}
+	return nil, fmt.Errorf("unknown audit rule '%s'", name)
+}
+
+// Disable は名前で指定したルールを無効にします
+func (c *AuditConfig) Disable(names ...string) error {
+	for _, name := range names {
+		r, err := c.Rule(name)
+		if err != nil {
+			return err
+		}
+		r.Enabled = false
+	}
+	return nil
+}
+
+// DefaultAuditConfig は既定の監査設定（すべてのルールが有効）を返します
+// 文字数は英語などのアルファベットの言語を想定した目安で、1文字の情報量が多い日本語・中国語・韓国語は短い範囲を使います
+func DefaultAuditConfig() AuditConfig {
+	return AuditConfig{
+		TitleLength: AuditLengthRule{AuditRule: AuditRule{Enabled: true, Severity: types.SeverityWarning}, Min: 30, Max: 60,
+			Languages: map[string]AuditLength{"ja": {Min: 15, Max: 32}, "zh": {Min: 15, Max: 30}, "ko": {Min: 15, Max: 30}}},
+		DescriptionLength: AuditLengthRule{AuditRule: AuditRule{Enabled: true, Severity: types.SeverityWarning}, Min: 70, Max: 160,
+			Languages: map[string]AuditLength{"ja": {Min: 50, Max: 120}, "zh": {Min: 40, Max: 80}, "ko": {Min: 40, Max: 80}}},
+		MissingDescription: AuditRule{Enabled: true, Severity: types.SeverityWarning},
+		MissingH1:          AuditRule{Enabled: true, Severity: types.SeverityWarning},
+		MultipleH1:         AuditRule{Enabled: true, Severity: types.SeverityInfo},
+		KeywordPlacement:   AuditRule{Enabled: true, Severity: types.SeverityWarning},
+		KeywordStuffing:    AuditDensityRule{AuditRule: AuditRule{Enabled: true, Severity: types.SeverityWarning}, MaxDensity: 0.1, MinCount: 10},
+		ImageAlt:           AuditRule{Enabled: true, Severity: types.SeverityInfo},
+		Noindex:            AuditRule{Enabled: true, Severity: types.SeverityError},
+	}
+}
+
 // Configに追加
 type Config struct {
-	Timeout           time.Duration
-	UserAgent         string
-	ScoreWeights      ScoreWeightConfig
-	MaxKeywords       int
-	IgnoreStopWords   bool
-	EnglishStopWords  map[string]int
+	Timeout          time.Duration
+	UserAgent        string
+	ScoreWeights     ScoreWeightConfig
+	MaxKeywords      int
+	IgnoreStopWords  bool
+	EnglishStopWords map[string]int
+	// EuropeanStopWords は言語コード（"de", "fr", "es", "it", "pt"）ごとのストップワード
+	EuropeanStopWords map[string]map[string]int
 	PluralSingularMap map[string]string
 	InvariantWords    map[string]bool
+	// MaxBodySize はレスポンスボディ（展開後）の上限バイト数（0以下で無制限）
+	MaxBodySize int64
+	// TruncateLargeBody が true なら上限超過分を切り捨てて解析、false ならエラーにする
+	TruncateLargeBody bool
+	// AllowedContentTypes は解析対象とするContent-Type（空なら全て許可）
+	AllowedContentTypes []string
+	// Algorithm はキーワードのスコアリング方式（"frequency", "textrank", "yake"）
+	Algorithm string
+	// TextRankWindow は TextRank で共起とみなす語数の幅
+	TextRankWindow int
+	// YakeWindow は YAKE で左右の文脈として数える語数の幅
+	YakeWindow int
+	// Normalization は正規化スコアの計算方式（"max", "sum", "softmax"）
+	Normalization string
+	// ProminenceBoost が true なら frequency 方式で h1 に現れる語・ページ冒頭に現れる語のスコアを加算する
+	ProminenceBoost bool
+	// EarlyPositionChars はページ冒頭とみなす文字数（ProminenceBoost 用）
+	EarlyPositionChars int
+	// DistinguishAnchorTypes が true なら内部リンクと外部リンクのテキストを別の重みで扱う
+	DistinguishAnchorTypes bool
+	// UnicodeNormalization が true ならキーワード抽出前に NFKC 正規化（全角英数字・半角カタカナの統一）とダッシュ・長音符の統一を行う
+	UnicodeNormalization bool
+	// FoldKana が true ならひらがなをカタカナにそろえて同じキーワードとして扱う（UnicodeNormalization 有効時）
+	FoldKana bool
+	// JapaneseDictionary は日本語の形態素解析に使う辞書（"ipa", "uni"）
+	JapaneseDictionary string
+	// JapaneseDictionaryFile は kagome 形式の辞書ファイルのパス（空なら JapaneseDictionary の組み込み辞書）
+	JapaneseDictionaryFile string
+	// JapaneseUserDictFile は kagome のユーザー辞書（CSV: 表層形,分割,読み,品詞）のパス。ブランド名・専門用語を1語として扱う
+	JapaneseUserDictFile string
+	// JapaneseStopWords は日本語のストップワード（表層形または基本形で比較、ユーザー辞書の語には適用しない）
+	JapaneseStopWords map[string]int
+	// JapaneseMergeByReading が true なら読みと漢字が同じ日本語のキーワード（「問合せ」と「問い合わせ」など）を同じ語として扱う
+	JapaneseMergeByReading bool
+	// JapaneseFilter は日本語のキーワード候補とする品詞・文字数の条件（JapaneseFilterPreset でプリセットを取得）
+	JapaneseFilter JapaneseFilterConfig
+	// Language はページの言語コード（"en", "ja", "zh" など）。空なら本文・<html lang>・Content-Language から自動判定
+	Language string
+	// Audit はオンページSEO監査のルールごとの設定（GetAnalysisResult の findings に出力）
+	Audit AuditConfig
 }
 
 type ScoreWeightConfig struct {
 	Title       int
 	MetaKeyword int
 	Description int
+	// MainContent は差し替えた DocumentParser の本文に使う重み（組み込みの解析では見出しレベルごとの重みを使う）
 	MainContent int
+	// H1〜H6 は見出しレベルごとの重み
+	H1 int
+	H2 int
+	H3 int
+	H4 int
+	H5 int
+	H6 int
+	// ImageAlt は img の alt 属性の重み
+	ImageAlt int
+	// Figcaption は figcaption の重み
+	Figcaption int
+	// AnchorText はリンクテキストの重み（DistinguishAnchorTypes が false の場合）
+	AnchorText int
+	// InternalAnchorText / ExternalAnchorText は内部・外部リンクのテキストの重み（DistinguishAnchorTypes が true の場合）
+	InternalAnchorText int
+	ExternalAnchorText int
+	// H1Boost は h1 に現れる語への加算（ProminenceBoost 有効時）
+	H1Boost int
+	// EarlyPositionBoost はページ冒頭に現れる語への加算（ProminenceBoost 有効時）
+	EarlyPositionBoost int
+}
+
+// HeadingWeight は見出しレベル（1〜6）の重みを返します（範囲外は 0）
+func (w ScoreWeightConfig) HeadingWeight(level int) int {
+	switch level {
+	case 1:
+		return w.H1
+	case 2:
+		return w.H2
+	case 3:
+		return w.H3
+	case 4:
+		return w.H4
+	case 5:
+		return w.H5
+	case 6:
+		return w.H6
+	}
+	return 0
 }
 
 // DefaultConfig はデフォルト設定を返します
+// 取得に関する設定（タイムアウト・User-Agent・ボディの上限・Content-Type）は fetcher.DefaultOptions に合わせます
 func DefaultConfig() Config {
+	fetch := fetcher.DefaultOptions(fetcher.DefaultTimeoutSeconds)
 	return Config{
-		Timeout:   10 * time.Second,
-		UserAgent: "Mozilla/5.0 (compatible; KeywordBot/1.0)",
+		Timeout:   time.Duration(fetch.TimeoutSeconds) * time.Second,
+		UserAgent: fetch.UserAgent,
 		ScoreWeights: ScoreWeightConfig{
-			Title:       5,
-			MetaKeyword: 8,
-			Description: 3,
-			MainContent: 1,
+			Title:              5,
+			MetaKeyword:        8,
+			Description:        3,
+			MainContent:        1,
+			H1:                 4,
+			H2:                 3,
+			H3:                 2,
+			H4:                 1,
+			H5:                 1,
+			H6:                 1,
+			ImageAlt:           2,
+			Figcaption:         2,
+			AnchorText:         1,
+			InternalAnchorText: 1,
+			ExternalAnchorText: 0,
+			H1Boost:            3,
+			EarlyPositionBoost: 2,
 		},
-		MaxKeywords:       20,
-		IgnoreStopWords:   false,
-		EnglishStopWords:  DefaultEnglishStopWords,
-		PluralSingularMap: DefaultPluralSingularMap,
-		InvariantWords:    DefaultInvariantWords,
+		MaxKeywords:            20,
+		IgnoreStopWords:        false,
+		EnglishStopWords:       DefaultEnglishStopWords,
+		EuropeanStopWords:      DefaultEuropeanStopWords,
+		PluralSingularMap:      DefaultPluralSingularMap,
+		InvariantWords:         DefaultInvariantWords,
+		MaxBodySize:            fetch.MaxBodySize,
+		TruncateLargeBody:      fetch.TruncateLargeBody,
+		AllowedContentTypes:    append([]string{}, fetch.AllowedContentTypes...),
+		Algorithm:              "frequency",
+		TextRankWindow:         4,
+		YakeWindow:             1,
+		Normalization:          "max",
+		ProminenceBoost:        false,
+		EarlyPositionChars:     200,
+		DistinguishAnchorTypes: false,
+		UnicodeNormalization:   true,
+		FoldKana:               false,
+		JapaneseDictionary:     "ipa",
+		JapaneseStopWords:      DefaultJapaneseStopWords,
+		JapaneseMergeByReading: false,
+		JapaneseFilter:         DefaultJapaneseFilter(),
+		Language:               "",
+		Audit:                  DefaultAuditConfig(),
 	}
 }
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/xshoji/go-site-keyword/internal/fetcher"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -16,7 +19,78 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.ScoreWeights.Title != 5 || cfg.ScoreWeights.MetaKeyword != 8 || cfg.ScoreWeights.Description != 3 || cfg.ScoreWeights.MainContent != 1 {
 		t.Errorf("unexpected ScoreWeights: %+v", cfg.ScoreWeights)
 	}
+	if cfg.ScoreWeights.HeadingWeight(1) != 4 || cfg.ScoreWeights.HeadingWeight(3) != 2 || cfg.ScoreWeights.HeadingWeight(6) != 1 || cfg.ScoreWeights.HeadingWeight(7) != 0 {
+		t.Errorf("unexpected heading weights: %+v", cfg.ScoreWeights)
+	}
+	if _, ok := cfg.JapaneseStopWords["こと"]; !ok || cfg.JapaneseMergeByReading {
+		t.Errorf("unexpected Japanese defaults: merge=%v", cfg.JapaneseMergeByReading)
+	}
 	if cfg.MaxKeywords != 20 {
 		t.Errorf("expected MaxKeywords 20, got %d", cfg.MaxKeywords)
 	}
 }
+
+func TestDefaultConfig_FetchDefaults(t *testing.T) {
+	// 取得に関する既定値は fetcher の既定値と一致させる
+	cfg := DefaultConfig()
+	fetch := fetcher.DefaultOptions(fetcher.DefaultTimeoutSeconds)
+	if cfg.Timeout != time.Duration(fetch.TimeoutSeconds)*time.Second || cfg.UserAgent != fetch.UserAgent ||
+		cfg.MaxBodySize != fetch.MaxBodySize || cfg.TruncateLargeBody != fetch.TruncateLargeBody ||
+		!reflect.DeepEqual(cfg.AllowedContentTypes, fetch.AllowedContentTypes) {
+		t.Errorf("expected fetch defaults to match fetcher.DefaultOptions, got %+v", cfg)
+	}
+	// 設定の変更が fetcher の既定値に影響しない
+	cfg.AllowedContentTypes[0] = "text/plain"
+	if fetcher.DefaultAllowedContentTypes[0] != "text/html" {
+		t.Error("expected DefaultConfig to copy the allowed content types")
+	}
+}
+
+func TestJapaneseFilterPreset(t *testing.T) {
+	f, err := JapaneseFilterPreset(JapaneseFilterDefault, "ipa")
+	if err != nil || len(f.Allow) != 0 || f.MinLength != 2 || f.KanjiMinLength != 1 {
+		t.Errorf("unexpected default preset: %+v, %v", f, err)
+	}
+	f, err = JapaneseFilterPreset(JapaneseFilterIntent, "uni")
+	if err != nil || !f.BaseForm || f.Allow[0] != "名詞,普通名詞" {
+		t.Errorf("unexpected intent preset for UniDic: %+v, %v", f, err)
+	}
+	if _, err := JapaneseFilterPreset("unknown", "ipa"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+}
+
+func TestAuditConfig(t *testing.T) {
+	cfg := DefaultConfig().Audit
+	for _, name := range AuditRuleNames {
+		r, err := cfg.Rule(name)
+		if err != nil || !r.Enabled || r.Severity == "" {
+			t.Errorf("expected rule %s enabled by default, got %+v, %v", name, r, err)
+		}
+	}
+	if err := cfg.Disable(AuditTitleLength, AuditNoindex); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TitleLength.Enabled || cfg.Noindex.Enabled || !cfg.MissingH1.Enabled {
+		t.Errorf("unexpected enabled rules: %+v", cfg)
+	}
+	if cfg.TitleLength.Min != 30 || cfg.TitleLength.Max != 60 {
+		t.Errorf("expected length thresholds to be kept, got %+v", cfg.TitleLength)
+	}
+	if err := cfg.Disable("unknown"); err == nil {
+		t.Error("expected error for unknown rule")
+	}
+}
+
+func TestAuditLengthRule_ForLanguage(t *testing.T) {
+	rule := DefaultAuditConfig().TitleLength
+	if r := rule.ForLanguage("ja"); r.Min != 15 || r.Max != 32 || !r.Enabled {
+		t.Errorf("expected Japanese title length, got %+v", r)
+	}
+	if r := rule.ForLanguage("en"); r.Min != 30 || r.Max != 60 {
+		t.Errorf("expected default title length for English, got %+v", r)
+	}
+	if r := DefaultAuditConfig().DescriptionLength.ForLanguage("ko"); r.Min != 40 || r.Max != 80 {
+		t.Errorf("expected Korean description length, got %+v", r)
+	}
+}
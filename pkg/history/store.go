@@ -0,0 +1,167 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// snapshotsBucket は URL ごとのバケットをまとめるバケット名
+var snapshotsBucket = []byte("snapshots")
+
+// Snapshot はある時点でのページの解析結果
+type Snapshot struct {
+	URL    string                `json:"url"`
+	Time   time.Time             `json:"time"`
+	Result *types.AnalysisResult `json:"result"`
+}
+
+// Store は解析結果の履歴を URL と時刻をキーに保存する組み込みDB（bbolt）
+type Store struct {
+	db *bolt.DB
+}
+
+// DefaultPath は既定のDBファイルのパス（~/.sitekeyword/history.db）を返します
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "history.db"
+	}
+	return filepath.Join(home, ".sitekeyword", "history.db")
+}
+
+// Open は path のDBを開きます（なければディレクトリごと作成します）
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("Failed to create directory for '%s': %w", path, err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open history database '%s': %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// OpenReadOnly は path の既存のDBを読み取り専用で開きます（一覧・推移の表示用。ファイルがなければ作成せずエラー）
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("Failed to open history database '%s': %w", path, err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open history database '%s': %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close はDBを閉じます
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save はスナップショットを保存します（同じ URL・時刻のスナップショットは上書き）
+func (s *Store) Save(snapshot Snapshot) error {
+	value, err := json.Marshal(snapshot.Result)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(snapshotsBucket)
+		if err != nil {
+			return err
+		}
+		b, err := root.CreateBucketIfNotExists([]byte(snapshot.URL))
+		if err != nil {
+			return err
+		}
+		return b.Put(timeKey(snapshot.Time), value)
+	})
+}
+
+// Snapshots は url のスナップショットを古い順に返します（なければ空）
+func (s *Store) Snapshots(url string) ([]Snapshot, error) {
+	var snapshots []Snapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := bucket(tx, url)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			snapshot, err := decode(url, k, v)
+			if err != nil {
+				return err
+			}
+			snapshots = append(snapshots, snapshot)
+			return nil
+		})
+	})
+	return snapshots, err
+}
+
+// Latest は url の最新のスナップショットを返します（なければ nil）
+func (s *Store) Latest(url string) (*Snapshot, error) {
+	var latest *Snapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := bucket(tx, url)
+		if b == nil {
+			return nil
+		}
+		k, v := b.Cursor().Last()
+		if k == nil {
+			return nil
+		}
+		snapshot, err := decode(url, k, v)
+		if err != nil {
+			return err
+		}
+		latest = &snapshot
+		return nil
+	})
+	return latest, err
+}
+
+// URLs は保存されている URL ごとのスナップショット数を返します
+func (s *Store) URLs() (map[string]int, error) {
+	urls := map[string]int{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(snapshotsBucket)
+		if root == nil {
+			return nil
+		}
+		return root.ForEachBucket(func(k []byte) error {
+			urls[string(k)] = root.Bucket(k).Stats().KeyN
+			return nil
+		})
+	})
+	return urls, err
+}
+
+func bucket(tx *bolt.Tx, url string) *bolt.Bucket {
+	root := tx.Bucket(snapshotsBucket)
+	if root == nil {
+		return nil
+	}
+	return root.Bucket([]byte(url))
+}
+
+// timeKey は時刻順に並ぶキー（UnixNano のビッグエンディアン）を返します
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return key
+}
+
+func decode(url string, k, v []byte) (Snapshot, error) {
+	result := &types.AnalysisResult{}
+	if err := json.Unmarshal(v, result); err != nil {
+		return Snapshot{}, fmt.Errorf("Failed to decode snapshot of '%s': %w", url, err)
+	}
+	return Snapshot{URL: url, Time: time.Unix(0, int64(binary.BigEndian.Uint64(k))).UTC(), Result: result}, nil
+}
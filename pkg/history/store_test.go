@@ -0,0 +1,114 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+func result(title string, keywords ...string) *types.AnalysisResult {
+	r := &types.AnalysisResult{Title: title}
+	for i, k := range keywords {
+		r.Keywords = append(r.Keywords, types.KeywordWithScore{Keyword: k, Score: 10 - i, NormalizedScore: float64(10-i) / 10})
+	}
+	return r
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "history.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 保存順に関係なく時刻順に返す
+	for _, s := range []Snapshot{
+		{URL: "https://example.com/", Time: base.Add(48 * time.Hour), Result: result("third", "go")},
+		{URL: "https://example.com/", Time: base, Result: result("first", "go")},
+		{URL: "https://example.com/", Time: base.Add(24 * time.Hour), Result: result("second", "go")},
+		{URL: "https://example.com/other", Time: base, Result: result("other")},
+	} {
+		if err := store.Save(s); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshots, err := store.Snapshots("https://example.com/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshots) != 3 || snapshots[0].Result.Title != "first" || snapshots[2].Result.Title != "third" || !snapshots[1].Time.Equal(base.Add(24*time.Hour)) {
+		t.Errorf("unexpected snapshots: %+v", snapshots)
+	}
+	latest, err := store.Latest("https://example.com/")
+	if err != nil || latest == nil || latest.Result.Title != "third" {
+		t.Errorf("unexpected latest snapshot: %+v, %v", latest, err)
+	}
+	if latest, err := store.Latest("https://example.com/missing"); err != nil || latest != nil {
+		t.Errorf("expected no snapshot, got %+v, %v", latest, err)
+	}
+	urls, err := store.URLs()
+	if err != nil || len(urls) != 2 || urls["https://example.com/"] != 3 {
+		t.Errorf("unexpected URLs: %v, %v", urls, err)
+	}
+}
+
+func TestStore_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Save(Snapshot{URL: "u", Time: time.Now(), Result: result("t", "go")}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	store, err = Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+	if snapshots, err := store.Snapshots("u"); err != nil || len(snapshots) != 1 || snapshots[0].Result.Keywords[0].Keyword != "go" {
+		t.Errorf("expected snapshot to persist, got %+v, %v", snapshots, err)
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	// 存在しないパスはエラーにし、ファイルを作らない
+	missing := filepath.Join(dir, "missing", "history.db")
+	if _, err := OpenReadOnly(missing); err == nil {
+		t.Error("expected error for missing database")
+	}
+	if _, err := os.Stat(filepath.Dir(missing)); !os.IsNotExist(err) {
+		t.Errorf("expected no file to be created, got %v", err)
+	}
+
+	path := filepath.Join(dir, "history.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(Snapshot{URL: "u", Time: time.Now(), Result: result("t", "go")}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	store, err = OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+	if urls, err := store.URLs(); err != nil || urls["u"] != 1 {
+		t.Errorf("unexpected URLs: %v, %v", urls, err)
+	}
+	if err := store.Save(Snapshot{URL: "u", Time: time.Now(), Result: result("t")}); err == nil {
+		t.Error("expected error when saving to a read-only database")
+	}
+}
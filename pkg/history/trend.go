@@ -0,0 +1,115 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/config"
+)
+
+// History はページのキーワードの推移
+type History struct {
+	URL string `json:"url"`
+	// Times はスナップショットの時刻（古い順）
+	Times    []time.Time    `json:"times"`
+	Keywords []KeywordTrend `json:"keywords"`
+}
+
+// KeywordTrend はキーワードのスナップショットごとの順位とスコア
+type KeywordTrend struct {
+	Keyword string `json:"keyword"`
+	// Points は Times と同じ順のスナップショットごとの値
+	Points []TrendPoint `json:"points"`
+	// Delta は最初と最後のスナップショットの正規化スコアの差
+	Delta float64 `json:"delta"`
+}
+
+// TrendPoint はあるスナップショットでのキーワードの順位とスコア（上位にない場合は Rank が 0）
+type TrendPoint struct {
+	Rank            int     `json:"rank"`
+	Score           int     `json:"score"`
+	NormalizedScore float64 `json:"normalized_score"`
+}
+
+// NewHistory はスナップショット（古い順）から各スナップショットの上位 topN のキーワードの推移を求めます
+// 最新のスナップショットの順位順、最新で上位にないキーワードは最後に上位だった時の順位順に並べます
+// キーワードは既定の設定での集計キー（analyzer.KeywordKey）で対応づけます
+func NewHistory(url string, snapshots []Snapshot, topN int) *History {
+	h := &History{URL: url, Times: []time.Time{}, Keywords: []KeywordTrend{}}
+	cfg := config.DefaultConfig()
+	index := map[string]int{}
+	for i, snapshot := range snapshots {
+		h.Times = append(h.Times, snapshot.Time)
+		if snapshot.Result == nil {
+			continue
+		}
+		for rank, k := range snapshot.Result.Keywords {
+			if topN > 0 && rank >= topN {
+				break
+			}
+			key := analyzer.KeywordKey(k.Keyword, snapshot.Result.LanguageCode(), cfg)
+			j, ok := index[key]
+			if !ok {
+				j = len(h.Keywords)
+				index[key] = j
+				h.Keywords = append(h.Keywords, KeywordTrend{Keyword: k.Keyword, Points: make([]TrendPoint, len(snapshots))})
+			}
+			h.Keywords[j].Points[i] = TrendPoint{Rank: rank + 1, Score: k.Score, NormalizedScore: k.NormalizedScore}
+		}
+	}
+	for i := range h.Keywords {
+		points := h.Keywords[i].Points
+		h.Keywords[i].Delta = math.Round((points[len(points)-1].NormalizedScore-points[0].NormalizedScore)*10000) / 10000
+	}
+	sort.SliceStable(h.Keywords, func(i, j int) bool {
+		a, b := lastRank(h.Keywords[i].Points), lastRank(h.Keywords[j].Points)
+		if a.snapshot != b.snapshot {
+			return a.snapshot > b.snapshot
+		}
+		return a.rank < b.rank
+	})
+	return h
+}
+
+type rankAt struct {
+	snapshot int
+	rank     int
+}
+
+// lastRank は最後に上位だったスナップショットとその順位を返します
+func lastRank(points []TrendPoint) rankAt {
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Rank > 0 {
+			return rankAt{snapshot: i, rank: points[i].Rank}
+		}
+	}
+	return rankAt{snapshot: -1}
+}
+
+// WriteTable は推移を表形式（キーワードごとに「順位 (正規化スコア)」）で書き出します
+func (h *History) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "URL\t%s\n\n", h.URL)
+	header := "KEYWORD"
+	for _, t := range h.Times {
+		header += "\t" + t.Local().Format("2006-01-02 15:04")
+	}
+	fmt.Fprintln(tw, header+"\tDELTA")
+	for _, k := range h.Keywords {
+		row := k.Keyword
+		for _, p := range k.Points {
+			if p.Rank == 0 {
+				row += "\t-"
+				continue
+			}
+			row += fmt.Sprintf("\t%d (%.2f)", p.Rank, p.NormalizedScore)
+		}
+		fmt.Fprintf(tw, "%s\t%+.4f\n", row, k.Delta)
+	}
+	return tw.Flush()
+}
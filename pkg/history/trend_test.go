@@ -0,0 +1,55 @@
+package history
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHistory_NormalizedKey(t *testing.T) {
+	// 表記の揺れ（複数形・全角）は同じキーワードの推移としてまとめる
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{Time: base, Result: result("", "cities")},
+		{Time: base.Add(time.Hour), Result: result("", "Ｃｉｔｙ")},
+	}
+	h := NewHistory("https://example.com/", snapshots, 0)
+	if len(h.Keywords) != 1 || h.Keywords[0].Points[0].Rank != 1 || h.Keywords[0].Points[1].Rank != 1 {
+		t.Errorf("expected one merged keyword, got %+v", h.Keywords)
+	}
+}
+
+func TestNewHistory(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{Time: base, Result: result("", "go", "rust", "java")},
+		{Time: base.Add(7 * 24 * time.Hour), Result: result("", "Rust", "go", "python")},
+	}
+	h := NewHistory("https://example.com/", snapshots, 2)
+	var keywords []string
+	for _, k := range h.Keywords {
+		keywords = append(keywords, k.Keyword)
+	}
+	// 最新の順位順、最新で上位にない語（java は topN 外のため含まない）
+	if strings.Join(keywords, ",") != "rust,go" {
+		t.Fatalf("unexpected keywords: %v", keywords)
+	}
+	rust := h.Keywords[0]
+	if rust.Points[0].Rank != 2 || rust.Points[1].Rank != 1 || rust.Delta != 0.1 {
+		t.Errorf("unexpected rust trend: %+v", rust)
+	}
+
+	h = NewHistory("https://example.com/", snapshots, 0)
+	if len(h.Keywords) != 4 || h.Keywords[3].Keyword != "java" || h.Keywords[3].Points[1].Rank != 0 {
+		t.Errorf("expected dropped keywords last, got %+v", h.Keywords)
+	}
+
+	var buf bytes.Buffer
+	if err := h.WriteTable(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 (1.00)") || !strings.Contains(buf.String(), "-") {
+		t.Errorf("unexpected table:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,116 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/xshoji/go-site-keyword/pkg/config"
+)
+
+// CannibalizationOptions はカニバリゼーション判定の条件
+type CannibalizationOptions struct {
+	// TopN は各ページで上位とみなす順位（0以下なら DefaultCannibalizationTopN）
+	TopN int
+	// MinPages は競合とみなすページ数（2未満なら 2）
+	MinPages int
+	// Config はキーワードの対応づけに使う設定（ページの解析と同じもの。nil なら config.DefaultConfig()）
+	Config *config.Config
+}
+
+// DefaultCannibalizationTopN は各ページで上位とみなす既定の順位
+const DefaultCannibalizationTopN = 10
+
+// CannibalizationReport はサイト内で複数のページが同じキーワードで上位になっている状況の一覧
+type CannibalizationReport struct {
+	// Pages は解析したページ数
+	Pages    int                   `json:"pages"`
+	Keywords []CannibalizedKeyword `json:"keywords"`
+	Errors   []PageError           `json:"errors,omitempty"`
+}
+
+// CannibalizedKeyword は複数のページで上位になっているキーワードと競合するページ
+type CannibalizedKeyword struct {
+	Keyword string          `json:"keyword"`
+	Pages   []CompetingPage `json:"pages"`
+}
+
+// CompetingPage はキーワードで競合するページでのスコアと出現元
+type CompetingPage struct {
+	URL             string  `json:"url"`
+	Title           string  `json:"title"`
+	Rank            int     `json:"rank"`
+	Score           int     `json:"score"`
+	NormalizedScore float64 `json:"normalized_score"`
+	// Sources はキーワードの出現元（title, meta, h1 など）
+	Sources []string `json:"sources,omitempty"`
+}
+
+// Cannibalization は各ページの上位 TopN に入っているキーワードのうち、MinPages 以上のページで重複するものを返します
+// キーワードは競合ページ数、正規化スコアの合計の順に、ページは正規化スコアの高い順に並べます
+func Cannibalization(pages []Page, opts CannibalizationOptions) *CannibalizationReport {
+	topN := opts.TopN
+	if topN <= 0 {
+		topN = DefaultCannibalizationTopN
+	}
+	minPages := max(opts.MinPages, 2)
+
+	var order []string
+	groups := map[string]*CannibalizedKeyword{}
+	for _, page := range pages {
+		if page.Result == nil {
+			continue
+		}
+		for i, k := range page.Result.Keywords {
+			if i >= topN {
+				break
+			}
+			key := keywordKey(k.Keyword, page.Result, opts.Config)
+			group, ok := groups[key]
+			if !ok {
+				group = &CannibalizedKeyword{Keyword: k.Keyword}
+				groups[key] = group
+				order = append(order, key)
+			}
+			competing := CompetingPage{
+				URL:             page.URL,
+				Title:           page.Result.Title,
+				Rank:            i + 1,
+				Score:           k.Score,
+				NormalizedScore: k.NormalizedScore,
+			}
+			if k.Prominence != nil {
+				competing.Sources = k.Prominence.Sources
+			}
+			group.Pages = append(group.Pages, competing)
+		}
+	}
+
+	report := &CannibalizationReport{Pages: len(pages), Keywords: []CannibalizedKeyword{}}
+	total := map[string]float64{}
+	for _, key := range order {
+		group := groups[key]
+		if len(group.Pages) < minPages {
+			continue
+		}
+		sort.SliceStable(group.Pages, func(i, j int) bool {
+			if group.Pages[i].NormalizedScore != group.Pages[j].NormalizedScore {
+				return group.Pages[i].NormalizedScore > group.Pages[j].NormalizedScore
+			}
+			return group.Pages[i].URL < group.Pages[j].URL
+		})
+		for _, p := range group.Pages {
+			total[group.Keyword] += p.NormalizedScore
+		}
+		report.Keywords = append(report.Keywords, *group)
+	}
+	sort.SliceStable(report.Keywords, func(i, j int) bool {
+		a, b := report.Keywords[i], report.Keywords[j]
+		if len(a.Pages) != len(b.Pages) {
+			return len(a.Pages) > len(b.Pages)
+		}
+		if total[a.Keyword] != total[b.Keyword] {
+			return total[a.Keyword] > total[b.Keyword]
+		}
+		return a.Keyword < b.Keyword
+	})
+	return report
+}
@@ -0,0 +1,124 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+func TestCannibalization(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.html":         `<html><head><title>Running shoes guide</title></head><body><h1>Running shoes</h1></body></html>`,
+		"blog/b.htm":     `<html><head><title>Best running shoes</title><meta name="description" content="running shoes review"></head><body><p>shoes</p></body></html>`,
+		"c.html":         `<html><head><title>Tennis rackets</title></head><body><h1>Tennis</h1></body></html>`,
+		"notes/memo.txt": `running shoes`,
+	}
+	for name, html := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(html), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sources, err := HTMLFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sources) != 3 {
+		t.Fatalf("expected 3 HTML files, got %v", sources)
+	}
+	sources = append(sources, filepath.Join(dir, "missing.html"))
+
+	pages, pageErrors, err := AnalyzePagesContext(context.Background(), sources, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != 3 || len(pageErrors) != 1 {
+		t.Fatalf("expected 3 pages and 1 error, got %d pages, errors %v", len(pages), pageErrors)
+	}
+
+	report := Cannibalization(pages, CannibalizationOptions{})
+	if report.Pages != 3 || len(report.Keywords) != 2 {
+		t.Fatalf("expected running and shoes to compete, got %+v", report.Keywords)
+	}
+	for _, k := range report.Keywords {
+		if len(k.Pages) != 2 {
+			t.Errorf("expected 2 competing pages for %s, got %+v", k.Keyword, k.Pages)
+		}
+		for _, p := range k.Pages {
+			if p.Title == "" || p.Rank == 0 || len(p.Sources) == 0 || p.Sources[0] != "title" {
+				t.Errorf("unexpected competing page for %s: %+v", k.Keyword, p)
+			}
+		}
+	}
+	// 3ページ以上で競合するキーワードはない
+	if report := Cannibalization(pages, CannibalizationOptions{MinPages: 3}); len(report.Keywords) != 0 {
+		t.Errorf("expected no keywords competing on 3 pages, got %+v", report.Keywords)
+	}
+}
+
+func TestCannibalization_CaseInsensitive(t *testing.T) {
+	pages := []Page{
+		{URL: "/a", Result: &types.AnalysisResult{Keywords: []types.KeywordWithScore{{Keyword: "Go", Score: 5, NormalizedScore: 1}}}},
+		{URL: "/b", Result: &types.AnalysisResult{Keywords: []types.KeywordWithScore{{Keyword: "go", Score: 3, NormalizedScore: 0.5}}}},
+		{URL: "/c", Result: &types.AnalysisResult{Keywords: []types.KeywordWithScore{{Keyword: "go", Score: 3, NormalizedScore: 0.8}}}},
+	}
+	pages = append(pages, Page{URL: "/d", Result: &types.AnalysisResult{Keywords: []types.KeywordWithScore{{Keyword: "rust", NormalizedScore: 1}, {Keyword: "go", NormalizedScore: 0.9}}}})
+	report := Cannibalization(pages, CannibalizationOptions{MinPages: 3})
+	if len(report.Keywords) != 1 || len(report.Keywords[0].Pages) != 4 || report.Keywords[0].Pages[1].URL != "/d" {
+		t.Errorf("unexpected report: %+v", report.Keywords)
+	}
+	// 上位1件のみを対象にすると /d の go は数えない
+	report = Cannibalization(pages, CannibalizationOptions{TopN: 1, MinPages: 3})
+	if len(report.Keywords) != 1 || len(report.Keywords[0].Pages) != 3 {
+		t.Errorf("unexpected report for top 1: %+v", report.Keywords)
+	}
+}
+
+func TestCannibalization_NormalizedKey(t *testing.T) {
+	// 解析時と同じ集計キー（複数形・全角・語幹）でページ間のキーワードを対応づける
+	fr := &types.LanguageDetection{Code: "fr"}
+	pages := []Page{
+		{URL: "/a", Result: &types.AnalysisResult{Keywords: []types.KeywordWithScore{{Keyword: "cities", NormalizedScore: 1}}}},
+		{URL: "/b", Result: &types.AnalysisResult{Keywords: []types.KeywordWithScore{{Keyword: "Ｃｉｔｙ", NormalizedScore: 0.8}}}},
+		{URL: "/c", Result: &types.AnalysisResult{Language: fr, Keywords: []types.KeywordWithScore{{Keyword: "Entreprises", NormalizedScore: 1}}}},
+		{URL: "/d", Result: &types.AnalysisResult{Language: fr, Keywords: []types.KeywordWithScore{{Keyword: "entreprise", NormalizedScore: 0.9}}}},
+	}
+	report := Cannibalization(pages, CannibalizationOptions{})
+	if len(report.Keywords) != 2 {
+		t.Fatalf("expected cities and entreprises to compete, got %+v", report.Keywords)
+	}
+	for _, k := range report.Keywords {
+		if len(k.Pages) != 2 {
+			t.Errorf("expected 2 competing pages for %s, got %+v", k.Keyword, k.Pages)
+		}
+	}
+}
+
+func TestAnalyzePagesContext_URL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><title>Example page</title></head></html>`))
+	}))
+	defer server.Close()
+
+	pages, pageErrors, err := AnalyzePagesContext(context.Background(), []string{server.URL}, 10)
+	if err != nil || len(pageErrors) != 0 || len(pages) != 1 || pages[0].Result.Title != "Example page" {
+		t.Errorf("unexpected result: %+v, %v, %v", pages, pageErrors, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := AnalyzePagesContext(ctx, []string{server.URL}, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
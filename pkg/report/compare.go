@@ -0,0 +1,208 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/xshoji/go-site-keyword/pkg/config"
+)
+
+// CompareOptions はキーワードギャップ分析の条件
+type CompareOptions struct {
+	// MinCompetitorScore は競合で強いとみなす正規化スコアの下限（0以下なら DefaultMinCompetitorScore）
+	MinCompetitorScore float64
+	// GapThreshold は自サイトが弱いとみなす競合との正規化スコアの差（0以下なら DefaultGapThreshold）
+	GapThreshold float64
+	// Config はキーワードの対応づけに使う設定（ページの解析と同じもの。nil なら config.DefaultConfig()）
+	Config *config.Config
+}
+
+// 比較の既定値
+const (
+	DefaultMinCompetitorScore = 0.3
+	DefaultGapThreshold       = 0.2
+)
+
+// CompareReport は自サイトと競合サイトのキーワード比較の結果
+type CompareReport struct {
+	// Ours は自サイトの URL
+	Ours        string   `json:"ours"`
+	Competitors []string `json:"competitors"`
+	// Gaps は競合で強く、自サイトにない・弱いキーワード（差の大きい順）
+	Gaps []KeywordComparison `json:"gaps"`
+	// Shared は自サイトと競合の両方にあるキーワード（自サイトが弱い順）
+	Shared []KeywordComparison `json:"shared"`
+	// Unique はサイトごとの、他のサイトにないキーワード
+	Unique []SiteKeywords `json:"unique"`
+	Errors []PageError    `json:"errors,omitempty"`
+}
+
+// KeywordComparison はキーワードのサイトごとの正規化スコア
+type KeywordComparison struct {
+	Keyword string `json:"keyword"`
+	// Scores は URL ごとの正規化スコア（キーワードがないサイトは含まない）
+	Scores map[string]float64 `json:"scores"`
+	// Ours は自サイトの正規化スコア（ない場合は 0）
+	Ours float64 `json:"ours"`
+	// BestCompetitor は競合の正規化スコアの最大値
+	BestCompetitor float64 `json:"best_competitor"`
+	// Delta は Ours - BestCompetitor
+	Delta float64 `json:"delta"`
+}
+
+// SiteKeywords はサイトとキーワードの一覧
+type SiteKeywords struct {
+	URL      string   `json:"url"`
+	Keywords []string `json:"keywords"`
+}
+
+// CheckDuplicateURLs は同じ URL が複数回指定されていればエラーを返します
+func CheckDuplicateURLs(urls []string) error {
+	seen := map[string]bool{}
+	for _, url := range urls {
+		if seen[url] {
+			return fmt.Errorf("Duplicate URL '%s': each page can be compared only once", url)
+		}
+		seen[url] = true
+	}
+	return nil
+}
+
+// Compare は ours と competitors のキーワードを比較します
+// ページ間でスコアをそろえるため、各ページは同じ Config（正規化方式）で解析してください
+// スコアは URL ごとに集計するため、同じ URL のページ（競合に自サイトを含む場合も）があればエラーを返します
+func Compare(ours Page, competitors []Page, opts CompareOptions) (*CompareReport, error) {
+	urls := []string{ours.URL}
+	for _, c := range competitors {
+		urls = append(urls, c.URL)
+	}
+	if err := CheckDuplicateURLs(urls); err != nil {
+		return nil, err
+	}
+	minScore := opts.MinCompetitorScore
+	if minScore <= 0 {
+		minScore = DefaultMinCompetitorScore
+	}
+	threshold := opts.GapThreshold
+	if threshold <= 0 {
+		threshold = DefaultGapThreshold
+	}
+
+	report := &CompareReport{Ours: ours.URL, Competitors: []string{}, Gaps: []KeywordComparison{}, Shared: []KeywordComparison{}, Unique: []SiteKeywords{}}
+	sites := append([]Page{ours}, competitors...)
+	var order []string
+	comparisons := map[string]*KeywordComparison{}
+	for i, site := range sites {
+		if i > 0 {
+			report.Competitors = append(report.Competitors, site.URL)
+		}
+		if site.Result == nil {
+			continue
+		}
+		for _, k := range site.Result.Keywords {
+			key := keywordKey(k.Keyword, site.Result, opts.Config)
+			c, ok := comparisons[key]
+			if !ok {
+				c = &KeywordComparison{Keyword: k.Keyword, Scores: map[string]float64{}}
+				comparisons[key] = c
+				order = append(order, key)
+			}
+			// 同じページで集計キーが同じキーワードは上位のものだけを使う
+			if _, seen := c.Scores[site.URL]; seen {
+				continue
+			}
+			c.Scores[site.URL] = k.NormalizedScore
+			if i == 0 {
+				c.Ours = k.NormalizedScore
+			} else {
+				c.BestCompetitor = math.Max(c.BestCompetitor, k.NormalizedScore)
+			}
+		}
+	}
+
+	unique := map[string][]string{}
+	for _, key := range order {
+		c := comparisons[key]
+		c.Delta = round4(c.Ours - c.BestCompetitor)
+		_, inOurs := c.Scores[ours.URL]
+		if len(c.Scores) == 1 {
+			for url := range c.Scores {
+				unique[url] = append(unique[url], c.Keyword)
+			}
+		}
+		if inOurs && len(c.Scores) > 1 {
+			report.Shared = append(report.Shared, *c)
+		}
+		if c.BestCompetitor >= minScore && (!inOurs || -c.Delta >= threshold) {
+			report.Gaps = append(report.Gaps, *c)
+		}
+	}
+	sort.SliceStable(report.Gaps, func(i, j int) bool {
+		if report.Gaps[i].Delta != report.Gaps[j].Delta {
+			return report.Gaps[i].Delta < report.Gaps[j].Delta
+		}
+		return report.Gaps[i].Keyword < report.Gaps[j].Keyword
+	})
+	sort.SliceStable(report.Shared, func(i, j int) bool {
+		if report.Shared[i].Delta != report.Shared[j].Delta {
+			return report.Shared[i].Delta < report.Shared[j].Delta
+		}
+		return report.Shared[i].Keyword < report.Shared[j].Keyword
+	})
+	for _, site := range sites {
+		report.Unique = append(report.Unique, SiteKeywords{URL: site.URL, Keywords: append([]string{}, unique[site.URL]...)})
+	}
+	return report, nil
+}
+
+func round4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// WriteTable は比較結果を表形式で書き出します
+func (r *CompareReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	sites := append([]string{r.Ours}, r.Competitors...)
+	header := "KEYWORD\tOURS"
+	for i := range r.Competitors {
+		header += fmt.Sprintf("\tCOMP%d", i+1)
+	}
+	header += "\tDELTA"
+	writeSection := func(title string, rows []KeywordComparison) {
+		fmt.Fprintf(tw, "%s\n%s\n", title, header)
+		for _, c := range rows {
+			row := c.Keyword
+			for _, url := range sites {
+				if score, ok := c.Scores[url]; ok {
+					row += fmt.Sprintf("\t%.4f", score)
+				} else {
+					row += "\t-"
+				}
+			}
+			fmt.Fprintf(tw, "%s\t%+.4f\n", row, c.Delta)
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "OURS\t%s\n", r.Ours)
+	for i, url := range r.Competitors {
+		fmt.Fprintf(tw, "COMP%d\t%s\n", i+1, url)
+	}
+	fmt.Fprintln(tw)
+	writeSection("# Gaps", r.Gaps)
+	writeSection("# Shared", r.Shared)
+	fmt.Fprintln(tw, "# Unique")
+	for _, u := range r.Unique {
+		fmt.Fprintf(tw, "%s\t%s\n", u.URL, strings.Join(u.Keywords, ", "))
+	}
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(tw, "\n# Errors")
+		for _, e := range r.Errors {
+			fmt.Fprintf(tw, "%s\t%s\n", e.URL, e.Error)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+func page(url string, keywords map[string]float64) Page {
+	result := &types.AnalysisResult{}
+	for k, score := range keywords {
+		result.Keywords = append(result.Keywords, types.KeywordWithScore{Keyword: k, NormalizedScore: score})
+	}
+	return Page{URL: url, Result: result}
+}
+
+func TestCompare(t *testing.T) {
+	ours := page("ours", map[string]float64{"Shoes": 1, "running": 0.2, "blog": 0.5})
+	competitors := []Page{
+		page("comp1", map[string]float64{"shoes": 0.8, "running": 1, "trail": 0.6}),
+		page("comp2", map[string]float64{"ＳＨＯＥＳ": 0.4, "marathon": 0.1, "trail": 0.9}),
+	}
+	report, err := Compare(ours, competitors, CompareOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gaps []string
+	for _, g := range report.Gaps {
+		gaps = append(gaps, g.Keyword)
+	}
+	// trail は自サイトになく、running は自サイトが弱い。marathon はスコアが低いため除く
+	if strings.Join(gaps, ",") != "trail,running" {
+		t.Errorf("unexpected gaps: %+v", report.Gaps)
+	}
+	if g := report.Gaps[1]; g.Ours != 0.2 || g.BestCompetitor != 1 || g.Delta != -0.8 {
+		t.Errorf("unexpected running gap: %+v", g)
+	}
+
+	if len(report.Shared) != 2 || report.Shared[0].Keyword != "running" || report.Shared[1].Keyword != "Shoes" {
+		t.Fatalf("unexpected shared keywords: %+v", report.Shared)
+	}
+	if s := report.Shared[1]; len(s.Scores) != 3 || s.Delta != 0.2 {
+		t.Errorf("expected width and case variants to line up across sites: %+v", s)
+	}
+
+	unique := map[string]string{}
+	for _, u := range report.Unique {
+		unique[u.URL] = strings.Join(u.Keywords, ",")
+	}
+	if unique["ours"] != "blog" || unique["comp1"] != "" || unique["comp2"] != "marathon" {
+		t.Errorf("unexpected unique keywords: %+v", report.Unique)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteTable(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"# Gaps", "COMP2  comp2", "trail", "+0.2000", "# Unique"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in table output:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCompare_DuplicateURL(t *testing.T) {
+	ours := page("ours", map[string]float64{"shoes": 1})
+	// 競合に自サイトと同じ URL、または同じ競合が2回あると集計できないためエラー
+	for _, competitors := range [][]Page{
+		{page("ours", map[string]float64{"trail": 1})},
+		{page("comp1", map[string]float64{"trail": 1}), page("comp1", map[string]float64{"running": 1})},
+	} {
+		if _, err := Compare(ours, competitors, CompareOptions{}); err == nil {
+			t.Errorf("expected error for duplicate URLs in %+v", competitors)
+		}
+	}
+}
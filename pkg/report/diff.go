@@ -0,0 +1,210 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// DiffOptions は差分の条件
+type DiffOptions struct {
+	// MinScoreDelta は順位が変わらないキーワードを変化ありとみなす正規化スコアの差（0 ならわずかな差でも含める）
+	MinScoreDelta float64
+	// Config はキーワードの対応づけに使う設定（解析と同じもの。nil なら config.DefaultConfig()）
+	Config *config.Config
+}
+
+// ResultDiff は2つの解析結果の差分
+type ResultDiff struct {
+	// Old, New は比較した結果の出所（ファイル名・URL）
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Title はタイトルが変わった場合のみ設定
+	Title    *TextChange  `json:"title,omitempty"`
+	MetaTags []TextChange `json:"meta_tags"`
+	// Added は新しい結果にだけあるキーワード（新しい順位順）
+	Added []KeywordChange `json:"added"`
+	// Removed は古い結果にだけあるキーワード（古い順位順）
+	Removed []KeywordChange `json:"removed"`
+	// Changed は順位・スコアが変わったキーワード（スコアの差が大きい順）
+	Changed []KeywordChange `json:"changed"`
+}
+
+// TextChange はタイトル・メタタグの変更（追加の場合は Old、削除の場合は New が空）
+type TextChange struct {
+	Name string `json:"name,omitempty"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// KeywordChange はキーワードの順位と正規化スコアの変化（ない場合は順位 0）
+type KeywordChange struct {
+	Keyword  string  `json:"keyword"`
+	OldRank  int     `json:"old_rank"`
+	NewRank  int     `json:"new_rank"`
+	OldScore float64 `json:"old_score"`
+	NewScore float64 `json:"new_score"`
+	// ScoreDelta は NewScore - OldScore
+	ScoreDelta float64 `json:"score_delta"`
+}
+
+// HasChanges は差分があるか判定します
+func (d *ResultDiff) HasChanges() bool {
+	return d.Title != nil || len(d.MetaTags) > 0 || len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// Diff は old と new の解析結果を比較します（キーワードは解析時と同じ集計キーで対応づけます）
+func Diff(oldResult, newResult *types.AnalysisResult, opts DiffOptions) *ResultDiff {
+	d := &ResultDiff{MetaTags: []TextChange{}, Added: []KeywordChange{}, Removed: []KeywordChange{}, Changed: []KeywordChange{}}
+	if oldResult.Title != newResult.Title {
+		d.Title = &TextChange{Old: oldResult.Title, New: newResult.Title}
+	}
+	names := map[string]bool{}
+	for name := range oldResult.MetaTags {
+		names[name] = true
+	}
+	for name := range newResult.MetaTags {
+		names[name] = true
+	}
+	for name := range names {
+		if o, n := oldResult.MetaTags[name], newResult.MetaTags[name]; o != n {
+			d.MetaTags = append(d.MetaTags, TextChange{Name: name, Old: o, New: n})
+		}
+	}
+	sort.Slice(d.MetaTags, func(i, j int) bool { return d.MetaTags[i].Name < d.MetaTags[j].Name })
+
+	oldKeywords := map[string]int{}
+	for i, k := range oldResult.Keywords {
+		key := keywordKey(k.Keyword, oldResult, opts.Config)
+		if _, ok := oldKeywords[key]; !ok {
+			oldKeywords[key] = i
+		}
+	}
+	seen := map[string]bool{}
+	for i, k := range newResult.Keywords {
+		key := keywordKey(k.Keyword, newResult, opts.Config)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		change := KeywordChange{Keyword: k.Keyword, NewRank: i + 1, NewScore: k.NormalizedScore}
+		j, ok := oldKeywords[key]
+		if !ok {
+			change.ScoreDelta = round4(change.NewScore)
+			d.Added = append(d.Added, change)
+			continue
+		}
+		change.OldRank = j + 1
+		change.OldScore = oldResult.Keywords[j].NormalizedScore
+		change.ScoreDelta = round4(change.NewScore - change.OldScore)
+		if change.OldRank != change.NewRank || (change.ScoreDelta != 0 && math.Abs(change.ScoreDelta) >= opts.MinScoreDelta) {
+			d.Changed = append(d.Changed, change)
+		}
+	}
+	for key, j := range oldKeywords {
+		if seen[key] {
+			continue
+		}
+		k := oldResult.Keywords[j]
+		d.Removed = append(d.Removed, KeywordChange{Keyword: k.Keyword, OldRank: j + 1, OldScore: k.NormalizedScore, ScoreDelta: round4(-k.NormalizedScore)})
+	}
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].OldRank < d.Removed[j].OldRank })
+	sort.SliceStable(d.Changed, func(i, j int) bool {
+		a, b := math.Abs(d.Changed[i].ScoreDelta), math.Abs(d.Changed[j].ScoreDelta)
+		if a != b {
+			return a > b
+		}
+		return d.Changed[i].NewRank < d.Changed[j].NewRank
+	})
+	return d
+}
+
+// LoadResultContext は比較対象の解析結果を読み込みます
+// source が URL・HTML ファイル（.html, .htm）なら解析し、それ以外は sitekeyword --detail の JSON 出力として読み込みます
+func LoadResultContext(ctx context.Context, source string, maxKeywords int, opts ...analyzer.Option) (*types.AnalysisResult, error) {
+	ext := strings.ToLower(filepath.Ext(source))
+	if IsURL(source) || ext == ".html" || ext == ".htm" {
+		return analyzePageContext(ctx, source, maxKeywords, opts)
+	}
+	body, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read file '%s': %w", source, err)
+	}
+	result := &types.AnalysisResult{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("Failed to parse analysis result '%s': %w", source, err)
+	}
+	// --detail なしの出力にはタイトル・メタタグ・言語が無く、タイトルやメタタグの変更を比較できない
+	if result.Title == "" && len(result.MetaTags) == 0 && result.Language == nil {
+		return nil, fmt.Errorf("Analysis result '%s' has no title or meta tags: write it with --detail", source)
+	}
+	return result, nil
+}
+
+// ANSI エスケープシーケンス（WriteText の色付け）
+const (
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+// WriteText は差分を人が読める形式で書き出します（color が true なら追加を緑、削除を赤で色付け）
+func (d *ResultDiff) WriteText(w io.Writer, color bool) error {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", d.Old, d.New)
+	if d.Title != nil {
+		fmt.Fprintf(&b, "\nTitle:\n%s\n%s\n", paint(colorRed, "- "+d.Title.Old), paint(colorGreen, "+ "+d.Title.New))
+	}
+	if len(d.MetaTags) > 0 {
+		b.WriteString("\nMeta tags:\n")
+		for _, m := range d.MetaTags {
+			fmt.Fprintf(&b, "  %s\n", m.Name)
+			if m.Old != "" {
+				fmt.Fprintf(&b, "  %s\n", paint(colorRed, "- "+m.Old))
+			}
+			if m.New != "" {
+				fmt.Fprintf(&b, "  %s\n", paint(colorGreen, "+ "+m.New))
+			}
+		}
+	}
+	if len(d.Added)+len(d.Removed)+len(d.Changed) > 0 {
+		b.WriteString("\nKeywords:\n")
+	}
+	for _, k := range d.Added {
+		b.WriteString(paint(colorGreen, fmt.Sprintf("+ %s  #%d  %.4f (%+.4f)", k.Keyword, k.NewRank, k.NewScore, k.ScoreDelta)) + "\n")
+	}
+	for _, k := range d.Removed {
+		b.WriteString(paint(colorRed, fmt.Sprintf("- %s  #%d  %.4f (%+.4f)", k.Keyword, k.OldRank, k.OldScore, k.ScoreDelta)) + "\n")
+	}
+	for _, k := range d.Changed {
+		c := colorYellow
+		if k.ScoreDelta > 0 {
+			c = colorGreen
+		} else if k.ScoreDelta < 0 {
+			c = colorRed
+		}
+		b.WriteString(paint(c, fmt.Sprintf("~ %s  #%d -> #%d  %.4f -> %.4f (%+.4f)", k.Keyword, k.OldRank, k.NewRank, k.OldScore, k.NewScore, k.ScoreDelta)) + "\n")
+	}
+	if !d.HasChanges() {
+		b.WriteString("\nNo changes\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+func keywords(pairs ...interface{}) []types.KeywordWithScore {
+	var result []types.KeywordWithScore
+	for i := 0; i < len(pairs); i += 2 {
+		result = append(result, types.KeywordWithScore{Keyword: pairs[i].(string), NormalizedScore: pairs[i+1].(float64)})
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	oldResult := &types.AnalysisResult{
+		Title:    "Old title",
+		MetaTags: map[string]string{"description": "old", "keywords": "a,b"},
+		Keywords: keywords("go", 1.0, "Rust", 0.8, "java", 0.5, "tips", 0.3),
+	}
+	newResult := &types.AnalysisResult{
+		Title:    "New title",
+		MetaTags: map[string]string{"description": "new", "robots": "index"},
+		Keywords: keywords("rust", 1.0, "go", 0.9, "python", 0.6, "tips", 0.31),
+	}
+	d := Diff(oldResult, newResult, DiffOptions{})
+	if d.Title == nil || d.Title.Old != "Old title" || d.Title.New != "New title" {
+		t.Errorf("unexpected title change: %+v", d.Title)
+	}
+	if len(d.MetaTags) != 3 || d.MetaTags[0].Name != "description" || d.MetaTags[1].New != "" || d.MetaTags[2].Old != "" {
+		t.Errorf("unexpected meta tag changes: %+v", d.MetaTags)
+	}
+	if len(d.Added) != 1 || d.Added[0].Keyword != "python" || d.Added[0].NewRank != 3 {
+		t.Errorf("unexpected added keywords: %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].Keyword != "java" || d.Removed[0].ScoreDelta != -0.5 {
+		t.Errorf("unexpected removed keywords: %+v", d.Removed)
+	}
+	// rust は大文字・小文字を区別せず同じ語として扱い、スコアの差が大きい順に並べる
+	if len(d.Changed) != 3 || d.Changed[0].Keyword != "rust" || d.Changed[0].OldRank != 2 || d.Changed[0].NewRank != 1 || d.Changed[0].ScoreDelta != 0.2 {
+		t.Errorf("unexpected changed keywords: %+v", d.Changed)
+	}
+	// 順位が変わらない小さなスコアの差は除外できる
+	if d := Diff(oldResult, newResult, DiffOptions{MinScoreDelta: 0.05}); len(d.Changed) != 2 {
+		t.Errorf("expected small score changes to be ignored, got %+v", d.Changed)
+	}
+	if Diff(oldResult, oldResult, DiffOptions{}).HasChanges() {
+		t.Error("expected no changes for the same result")
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteText(&buf, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"- Old title", "+ New title", "+ python  #3", "- java  #3", "~ rust  #2 -> #1  0.8000 -> 1.0000 (+0.2000)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in text output:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Error("expected no color codes")
+	}
+	buf.Reset()
+	d.WriteText(&buf, true)
+	if !strings.Contains(buf.String(), colorGreen+"+ python") {
+		t.Errorf("expected colored output:\n%q", buf.String())
+	}
+}
+
+func TestLoadResultContext(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"title":"Go","meta_tags":{"description":"Go"},"keywords":[{"keyword":"go","score":5,"normalized_score":1}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	htmlPath := filepath.Join(dir, "new.html")
+	if err := os.WriteFile(htmlPath, []byte(`<html><head><title>Go tips</title></head></html>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	oldResult, err := LoadResultContext(ctx, jsonPath, 10)
+	if err != nil || len(oldResult.Keywords) != 1 {
+		t.Fatalf("unexpected result: %+v, %v", oldResult, err)
+	}
+	newResult, err := LoadResultContext(ctx, htmlPath, 10)
+	if err != nil || newResult.Title != "Go tips" {
+		t.Fatalf("unexpected result: %+v, %v", newResult, err)
+	}
+	if d := Diff(oldResult, newResult, DiffOptions{}); len(d.Added) != 1 || d.Added[0].Keyword != "tips" {
+		t.Errorf("unexpected diff: %+v", d)
+	}
+	// キーワードのみの出力（--detail なし）はタイトルやメタタグを比較できないのでエラー
+	for _, body := range []string{`{"keywords":[{"keyword":"go","score":5,"normalized_score":1}]}`, `{}`} {
+		path := filepath.Join(dir, "keywords.json")
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadResultContext(ctx, path, 10); err == nil {
+			t.Errorf("expected error for %s", body)
+		}
+	}
+	// タイトルの無いページの詳細出力は言語を持つので読み込める
+	langPath := filepath.Join(dir, "lang.json")
+	if err := os.WriteFile(langPath, []byte(`{"language":{"code":"en","confidence":1},"keywords":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadResultContext(ctx, langPath, 10); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := LoadResultContext(ctx, filepath.Join(dir, "missing.json"), 10); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
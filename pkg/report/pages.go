@@ -0,0 +1,103 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/config"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// Page は解析済みのページ
+type Page struct {
+	// URL はページの URL（ローカルファイルの場合はファイルのパス）
+	URL    string
+	Result *types.AnalysisResult
+}
+
+// PageError は解析できなかったページとその理由
+type PageError struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// keywordKey は result のキーワードを解析時と同じ集計キーにします（cfg が nil なら既定の設定）
+// ページ間・結果間で表記の揺れ（大文字・小文字、全角・半角、活用形など）があっても同じキーワードとして対応づけます
+func keywordKey(keyword string, result *types.AnalysisResult, cfg *config.Config) string {
+	c := config.DefaultConfig()
+	if cfg != nil {
+		c = *cfg
+	}
+	return analyzer.KeywordKey(keyword, result.LanguageCode(), c)
+}
+
+// IsURL は source が http(s) の URL か判定します（それ以外はローカルファイルとして扱う）
+func IsURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// HTMLFiles は dir 以下の HTML ファイル（.html, .htm）のパスを辞書順で返します
+func HTMLFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := strings.ToLower(filepath.Ext(path)); !d.IsDir() && (ext == ".html" || ext == ".htm") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read directory '%s': %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// AnalyzePagesContext は URL またはローカルの HTML ファイルを順に解析します
+// 解析できなかったページは PageError として返し、残りのページの解析を続けます
+// ctx がキャンセルされた場合は ctx.Err() を返します
+func AnalyzePagesContext(ctx context.Context, sources []string, maxKeywords int, opts ...analyzer.Option) ([]Page, []PageError, error) {
+	var pages []Page
+	var pageErrors []PageError
+	for _, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		result, err := analyzePageContext(ctx, source, maxKeywords, opts)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, nil, ctxErr
+			}
+			pageErrors = append(pageErrors, PageError{URL: source, Error: err.Error()})
+			continue
+		}
+		pages = append(pages, Page{URL: source, Result: result})
+	}
+	return pages, pageErrors, nil
+}
+
+func analyzePageContext(ctx context.Context, source string, maxKeywords int, opts []analyzer.Option) (*types.AnalysisResult, error) {
+	a := analyzer.New(opts...)
+	if IsURL(source) {
+		if err := a.Load(ctx, source); err != nil {
+			return nil, err
+		}
+	} else {
+		body, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read file '%s': %w", source, err)
+		}
+		if err := a.LoadHTML(source, body); err != nil {
+			return nil, err
+		}
+	}
+	return a.GetAnalysisResultContext(ctx, maxKeywords)
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -17,13 +18,74 @@ type PageData struct {
 type KeywordWithScore struct {
 	Keyword string `json:"keyword"`
 	Score   int    `json:"score"`
+	// NormalizedScore はページ間で比較できる 0〜1 のスコア（正規化方式は config.Config.Normalization）
+	NormalizedScore float64 `json:"normalized_score"`
+	// Percentage は全候補のスコア合計に占める割合（%）
+	Percentage float64 `json:"percentage"`
+	// Prominence はページ内での出現位置・出現元（GetAnalysisResult でのみ設定）
+	Prominence *KeywordProminence `json:"prominence,omitempty"`
+}
+
+// KeywordProminence はキーワードのページ内での出現位置と出現元の構造体
+type KeywordProminence struct {
+	// FirstOffset はページ全体のテキストでの初出の文字位置
+	FirstOffset int `json:"first_offset"`
+	// Sources は出現元（title, meta, h1, h2, body, alt, anchor）を初出順に並べたもの
+	Sources []string `json:"sources"`
+	// DOMPath は初出の要素の位置
+	DOMPath string `json:"dom_path"`
+	// Counts は出現元ごとの出現回数
+	Counts map[string]int `json:"counts"`
 }
 
 // AnalysisResult はウェブページの解析結果を表す構造体
 type AnalysisResult struct {
 	Title    string             `json:"title,omitempty"`
 	MetaTags map[string]string  `json:"meta_tags,omitempty"`
+	Language *LanguageDetection `json:"language,omitempty"`
+	Outline  []HeadingOutline   `json:"outline,omitempty"`
 	Keywords []KeywordWithScore `json:"keywords,omitempty"`
+	// Findings はオンページSEOの監査結果（config.Config.Audit で有効にしたルールのみ）
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// LanguageCode は結果の言語コードを返します（言語を出力していない結果では空）
+func (r *AnalysisResult) LanguageCode() string {
+	if r == nil || r.Language == nil {
+		return ""
+	}
+	return r.Language.Code
+}
+
+// 監査結果の重要度
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Finding はオンページSEOの監査で見つかった問題を表す構造体
+type Finding struct {
+	// Rule はルール名（"title-length", "missing-h1" など）
+	Rule string `json:"rule"`
+	// Severity は重要度（"info", "warning", "error"）
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// LanguageDetection はページの言語判定結果を表す構造体
+type LanguageDetection struct {
+	// Code は言語コード（判定できない場合は "und"）
+	Code string `json:"code"`
+	// Confidence は 0〜1 の確からしさ（Config.Language で指定した場合は 1）
+	Confidence float64 `json:"confidence"`
+}
+
+// HeadingOutline は見出し構造（h1〜h6 の入れ子）を表す構造体
+type HeadingOutline struct {
+	Level    int              `json:"level"`
+	Text     string           `json:"text"`
+	Children []HeadingOutline `json:"children,omitempty"`
 }
 
 // PageFetcher: ページ取得のインターフェース
@@ -31,6 +93,12 @@ type PageFetcher interface {
 	Fetch(url string, timeout time.Duration) ([]byte, error)
 }
 
+// ContextPageFetcher: context 対応のページ取得インターフェース（PageFetcher の拡張）
+type ContextPageFetcher interface {
+	PageFetcher
+	FetchContext(ctx context.Context, url string, timeout time.Duration) ([]byte, error)
+}
+
 // KeywordExtractor: キーワード抽出のインターフェース
 type KeywordExtractor interface {
 	Extract(text string) ([]KeywordWithScore, error)
@@ -42,3 +110,9 @@ type DocumentParser interface {
 	ParseMetaTags(doc *goquery.Document) (map[string]string, error)
 	ParseMainContent(doc *goquery.Document) (string, error)
 }
+
+// KeywordScorer: キーワードのランク付けのインターフェース
+// scores は正規化キーごとの合計スコア、originals は表示用の元の表記
+type KeywordScorer interface {
+	Rank(scores map[string]int, originals map[string]string, limit int) []KeywordWithScore
+}
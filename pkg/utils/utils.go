@@ -1,6 +1,9 @@
 package utils
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 // NormalizeSpace は文字列の余分な空白を1つにまとめてトリムします
 func NormalizeSpace(s string) string {
@@ -19,3 +22,16 @@ func UniqueStrings(input []string) []string {
 	}
 	return result
 }
+
+var sentenceDelimiter = regexp.MustCompile(`[.!?。！？]+(\s+|$)|[。！？]+|\n+`)
+
+// SplitSentences は文末記号・改行で文に分割し、空の文を除いて返します
+func SplitSentences(s string) []string {
+	var result []string
+	for _, sentence := range sentenceDelimiter.Split(s, -1) {
+		if sentence = NormalizeSpace(sentence); sentence != "" {
+			result = append(result, sentence)
+		}
+	}
+	return result
+}
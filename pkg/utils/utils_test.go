@@ -28,3 +28,16 @@ func TestUniqueStrings(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitSentences(t *testing.T) {
+	out := SplitSentences("Go is fast. Go is simple!\n日本語の文。次の文")
+	expected := []string{"Go is fast", "Go is simple", "日本語の文", "次の文"}
+	if len(out) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+	for i := range expected {
+		if out[i] != expected[i] {
+			t.Errorf("expected '%s', got '%s'", expected[i], out[i])
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/xshoji/go-site-keyword/pkg/analyzer"
+	"github.com/xshoji/go-site-keyword/pkg/history"
+	"github.com/xshoji/go-site-keyword/pkg/report"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// 監視の既定値
+const (
+	DefaultTopN          = 10
+	DefaultMinScoreDelta = 0.1
+)
+
+// Options は監視の設定
+type Options struct {
+	// URLs は監視するページ
+	URLs []string
+	// Interval は再解析の間隔
+	Interval time.Duration
+	// WebhookURL は変化を検知したときに Alert を JSON で POST する URL（空なら送信しない）
+	WebhookURL string
+	// TopN は比較する上位キーワードの件数（0以下なら DefaultTopN）
+	TopN int
+	// MinScoreDelta はキーワードの追加・削除・スコアの変化を通知する正規化スコアの差の下限（0以下なら DefaultMinScoreDelta）
+	MinScoreDelta float64
+	// Store を指定すると解析結果を保存し、再起動後も最後の結果と比較する
+	Store *history.Store
+	// HTTPClient は Webhook の送信に使うクライアント（nil なら http.DefaultClient）
+	HTTPClient *http.Client
+	// AnalyzerOptions はページの解析に使う Analyzer の設定
+	AnalyzerOptions []analyzer.Option
+}
+
+// Alert は Webhook に送る変化の内容
+type Alert struct {
+	URL        string             `json:"url"`
+	DetectedAt time.Time          `json:"detected_at"`
+	Changes    *report.ResultDiff `json:"changes"`
+}
+
+// Watcher はページを定期的に解析し、前回の結果からの変化を通知します
+type Watcher struct {
+	opts Options
+	mu   sync.Mutex
+	last map[string]*types.AnalysisResult
+}
+
+// New は Watcher を生成します
+func New(opts Options) *Watcher {
+	if opts.TopN <= 0 {
+		opts.TopN = DefaultTopN
+	}
+	if opts.MinScoreDelta <= 0 {
+		opts.MinScoreDelta = DefaultMinScoreDelta
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	return &Watcher{opts: opts, last: map[string]*types.AnalysisResult{}}
+}
+
+// RunContext は直ちに1回確認した後、Interval ごとに確認を繰り返します
+// onCheck には確認ごとの結果が渡されます。ctx がキャンセルされるまで戻らず、ctx.Err() を返します
+func (w *Watcher) RunContext(ctx context.Context, onCheck func(alerts []Alert, pageErrors []report.PageError)) error {
+	if w.opts.Interval <= 0 {
+		return fmt.Errorf("interval must be positive: %v", w.opts.Interval)
+	}
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		alerts, pageErrors, err := w.CheckContext(ctx)
+		if err != nil {
+			return err
+		}
+		if onCheck != nil {
+			onCheck(alerts, pageErrors)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckContext は全てのページを解析して前回の結果と比較し、変化があれば Webhook に送信します
+// 前回の結果がないページは今回の結果を基準として保存するだけです
+// 送信に成功するまで基準は更新しないため、送信に失敗した変化は次回の確認で再送されます（前回の結果を読めない場合も基準は更新しない）
+// 解析・送信に失敗したページは PageError として返し、ctx がキャンセルされた場合は ctx.Err() を返します
+func (w *Watcher) CheckContext(ctx context.Context) ([]Alert, []report.PageError, error) {
+	pages, pageErrors, err := report.AnalyzePagesContext(ctx, w.opts.URLs, w.opts.TopN, w.opts.AnalyzerOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var alerts []Alert
+	for _, page := range pages {
+		previous, err := w.previous(page.URL)
+		if err != nil {
+			// 前回の結果を読めない場合は基準を上書きせず、次回の確認で比較する
+			pageErrors = append(pageErrors, report.PageError{URL: page.URL, Error: err.Error()})
+			continue
+		}
+		now := time.Now().UTC()
+		if previous != nil {
+			if changes := w.changes(previous, page.Result); changes != nil {
+				alert := Alert{URL: page.URL, DetectedAt: now, Changes: changes}
+				alerts = append(alerts, alert)
+				if err := w.notifyContext(ctx, alert); err != nil {
+					if ctxErr := ctx.Err(); ctxErr != nil {
+						return nil, nil, ctxErr
+					}
+					pageErrors = append(pageErrors, report.PageError{URL: page.URL, Error: err.Error()})
+					continue
+				}
+			}
+		}
+		if err := w.save(page, now); err != nil {
+			pageErrors = append(pageErrors, report.PageError{URL: page.URL, Error: err.Error()})
+		}
+	}
+	return alerts, pageErrors, nil
+}
+
+// previous は前回の結果（メモリ上、なければ Store の最新）を返します
+func (w *Watcher) previous(url string) (*types.AnalysisResult, error) {
+	w.mu.Lock()
+	last, ok := w.last[url]
+	w.mu.Unlock()
+	if ok || w.opts.Store == nil {
+		return last, nil
+	}
+	snapshot, err := w.opts.Store.Latest(url)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return snapshot.Result, nil
+}
+
+func (w *Watcher) save(page report.Page, now time.Time) error {
+	w.mu.Lock()
+	w.last[page.URL] = page.Result
+	w.mu.Unlock()
+	if w.opts.Store == nil {
+		return nil
+	}
+	return w.opts.Store.Save(history.Snapshot{URL: page.URL, Time: now, Result: page.Result})
+}
+
+// changes はタイトル・メタディスクリプション・上位 TopN のキーワードの変化を返します（通知する変化がなければ nil）
+// キーワードの追加・削除・スコアの変化は、正規化スコアの差が MinScoreDelta 以上の場合のみ変化とみなします
+func (w *Watcher) changes(previous, current *types.AnalysisResult) *report.ResultDiff {
+	cfg := analyzer.New(w.opts.AnalyzerOptions...).Config
+	d := report.Diff(topN(previous, w.opts.TopN), topN(current, w.opts.TopN), report.DiffOptions{Config: &cfg})
+	var meta []report.TextChange
+	for _, m := range d.MetaTags {
+		if m.Name == "description" {
+			meta = append(meta, m)
+		}
+	}
+	d.MetaTags = append([]report.TextChange{}, meta...)
+	d.Added = w.significant(d.Added)
+	d.Removed = w.significant(d.Removed)
+	d.Changed = w.significant(d.Changed)
+	if !d.HasChanges() {
+		return nil
+	}
+	return d
+}
+
+// significant は正規化スコアの差が MinScoreDelta 以上の変化に絞ります（追加・削除は新旧のスコアそのものが差になる）
+func (w *Watcher) significant(keywords []report.KeywordChange) []report.KeywordChange {
+	result := []report.KeywordChange{}
+	for _, k := range keywords {
+		if math.Abs(k.ScoreDelta) >= w.opts.MinScoreDelta {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// topN は上位 n 件のキーワードに絞った結果を返します
+func topN(result *types.AnalysisResult, n int) *types.AnalysisResult {
+	trimmed := *result
+	if len(trimmed.Keywords) > n {
+		trimmed.Keywords = trimmed.Keywords[:n]
+	}
+	return &trimmed
+}
+
+// notifyContext は Alert を Webhook に POST します（2xx 以外はエラー）
+func (w *Watcher) notifyContext(ctx context.Context, alert Alert) error {
+	if w.opts.WebhookURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Failed to create webhook request '%s': %w", w.opts.WebhookURL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := w.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to send webhook '%s': %w", w.opts.WebhookURL, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("Failed to send webhook '%s': status %d", w.opts.WebhookURL, res.StatusCode)
+	}
+	return nil
+}
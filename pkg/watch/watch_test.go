@@ -0,0 +1,244 @@
+package watch
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/xshoji/go-site-keyword/pkg/history"
+	"github.com/xshoji/go-site-keyword/pkg/report"
+	"github.com/xshoji/go-site-keyword/pkg/types"
+)
+
+// page はテスト用のページを返すサーバー（内容を差し替え可能）
+type page struct {
+	mu   sync.Mutex
+	html string
+}
+
+func (p *page) set(html string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.html = html
+}
+
+func (p *page) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(p.html))
+}
+
+// webhook は受け取った Alert を記録するサーバー
+type webhook struct {
+	mu     sync.Mutex
+	alerts []Alert
+	status int
+}
+
+func (h *webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var alert Alert
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&alert) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	h.alerts = append(h.alerts, alert)
+	if h.status != 0 {
+		w.WriteHeader(h.status)
+	}
+}
+
+const (
+	original = `<html><head><title>Running shoes</title><meta name="description" content="Shoes for runners"></head><body><h1>Running shoes</h1></body></html>`
+	edited   = `<html><head><title>Tennis rackets</title><meta name="description" content="Rackets for players"></head><body><h1>Tennis rackets</h1></body></html>`
+)
+
+func TestCheckContext(t *testing.T) {
+	p := &page{html: original}
+	site := httptest.NewServer(p)
+	defer site.Close()
+	hook := &webhook{}
+	hookServer := httptest.NewServer(hook)
+	defer hookServer.Close()
+
+	w := New(Options{URLs: []string{site.URL}, WebhookURL: hookServer.URL})
+	ctx := context.Background()
+
+	// 初回は基準として保存するだけ
+	alerts, pageErrors, err := w.CheckContext(ctx)
+	if err != nil || len(alerts) != 0 || len(pageErrors) != 0 {
+		t.Fatalf("expected no alerts on first check, got %+v, %+v, %v", alerts, pageErrors, err)
+	}
+	// 変化がなければ通知しない
+	if alerts, _, _ := w.CheckContext(ctx); len(alerts) != 0 {
+		t.Fatalf("expected no alerts without changes, got %+v", alerts)
+	}
+
+	p.set(edited)
+	alerts, pageErrors, err = w.CheckContext(ctx)
+	if err != nil || len(alerts) != 1 || len(pageErrors) != 0 {
+		t.Fatalf("expected one alert, got %+v, %+v, %v", alerts, pageErrors, err)
+	}
+	if len(hook.alerts) != 1 {
+		t.Fatalf("expected webhook to receive one alert, got %d", len(hook.alerts))
+	}
+	got := hook.alerts[0]
+	if got.URL != site.URL || got.Changes.Title == nil || got.Changes.Title.New != "Tennis rackets" {
+		t.Errorf("unexpected alert: %+v", got)
+	}
+	if len(got.Changes.MetaTags) != 1 || got.Changes.MetaTags[0].Name != "description" {
+		t.Errorf("expected description change, got %+v", got.Changes.MetaTags)
+	}
+	if len(got.Changes.Added) == 0 || len(got.Changes.Removed) == 0 {
+		t.Errorf("expected keyword changes, got %+v", got.Changes)
+	}
+
+	// Webhook の失敗は PageError として返す
+	hook.mu.Lock()
+	hook.status = http.StatusInternalServerError
+	hook.mu.Unlock()
+	p.set(original)
+	alerts, pageErrors, err = w.CheckContext(ctx)
+	if err != nil || len(alerts) != 1 || len(pageErrors) != 1 {
+		t.Errorf("expected webhook error, got %+v, %+v, %v", alerts, pageErrors, err)
+	}
+	// 送信に失敗した変化は基準を更新せず、次回の確認で再送する
+	hook.mu.Lock()
+	hook.status = 0
+	hook.mu.Unlock()
+	alerts, pageErrors, err = w.CheckContext(ctx)
+	if err != nil || len(alerts) != 1 || len(pageErrors) != 0 {
+		t.Fatalf("expected the failed alert to be resent, got %+v, %+v, %v", alerts, pageErrors, err)
+	}
+	if alerts[0].Changes.Title == nil || alerts[0].Changes.Title.New != "Running shoes" {
+		t.Errorf("unexpected resent alert: %+v", alerts[0].Changes)
+	}
+	if alerts, _, _ := w.CheckContext(ctx); len(alerts) != 0 {
+		t.Errorf("expected no alerts after the resent alert, got %+v", alerts)
+	}
+}
+
+func TestChanges_MinScoreDelta(t *testing.T) {
+	keywords := func(scores map[string]float64, order ...string) *types.AnalysisResult {
+		result := &types.AnalysisResult{Title: "Shoes"}
+		for _, k := range order {
+			result.Keywords = append(result.Keywords, types.KeywordWithScore{Keyword: k, NormalizedScore: scores[k]})
+		}
+		return result
+	}
+	w := New(Options{MinScoreDelta: 0.1})
+	previous := keywords(map[string]float64{"shoes": 1, "running": 0.5, "socks": 0.05}, "shoes", "running", "socks")
+
+	// スコアの低いキーワードの追加・削除は通知しない
+	if d := w.changes(previous, keywords(map[string]float64{"shoes": 1, "running": 0.5, "laces": 0.05}, "shoes", "running", "laces")); d != nil {
+		t.Errorf("expected no changes for low-score keywords, got %+v", d)
+	}
+	d := w.changes(previous, keywords(map[string]float64{"shoes": 1, "trail": 0.5}, "shoes", "trail"))
+	if d == nil || len(d.Added) != 1 || d.Added[0].Keyword != "trail" || len(d.Removed) != 1 || d.Removed[0].Keyword != "running" {
+		t.Errorf("expected trail added and running removed, got %+v", d)
+	}
+}
+
+func TestCheckContext_Store(t *testing.T) {
+	p := &page{html: original}
+	site := httptest.NewServer(p)
+	defer site.Close()
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if _, _, err := New(Options{URLs: []string{site.URL}, Store: store}).CheckContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// 再起動しても保存した結果と比較する
+	p.set(edited)
+	alerts, _, err := New(Options{URLs: []string{site.URL}, Store: store}).CheckContext(ctx)
+	if err != nil || len(alerts) != 1 {
+		t.Errorf("expected alert compared with the stored result, got %+v, %v", alerts, err)
+	}
+	if snapshots, _ := store.Snapshots(site.URL); len(snapshots) != 2 {
+		t.Errorf("expected 2 snapshots, got %d", len(snapshots))
+	}
+}
+
+func TestCheckContext_StoreReadError(t *testing.T) {
+	p := &page{html: original}
+	site := httptest.NewServer(p)
+	defer site.Close()
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := history.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, _, err := New(Options{URLs: []string{site.URL}, Store: store}).CheckContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// 最新のスナップショットを壊して Latest をエラーにする
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, uint64(time.Now().Add(time.Hour).UnixNano()))
+		return tx.Bucket([]byte("snapshots")).Bucket([]byte(site.URL)).Put(key, []byte("{broken"))
+	})
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = history.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	p.set(edited)
+	alerts, pageErrors, err := New(Options{URLs: []string{site.URL}, Store: store}).CheckContext(ctx)
+	if err != nil || len(alerts) != 0 || len(pageErrors) != 1 {
+		t.Fatalf("expected a page error without alerts, got %+v, %+v, %v", alerts, pageErrors, err)
+	}
+	// 読めなかった基準を今回の結果で上書きしない
+	if urls, err := store.URLs(); err != nil || urls[site.URL] != 2 {
+		t.Errorf("expected no new snapshot, got %v, %v", urls, err)
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	p := &page{html: original}
+	site := httptest.NewServer(p)
+	defer site.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checks := 0
+	w := New(Options{URLs: []string{site.URL}, Interval: 10 * time.Millisecond})
+	err := w.RunContext(ctx, func(alerts []Alert, pageErrors []report.PageError) {
+		checks++
+		if checks == 3 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) || checks != 3 {
+		t.Errorf("expected context.Canceled after 3 checks, got %v, %d", err, checks)
+	}
+	if err := New(Options{}).RunContext(context.Background(), nil); err == nil {
+		t.Error("expected error without interval")
+	}
+}